@@ -0,0 +1,46 @@
+package timespec
+
+import "regexp"
+
+// leadingYear matches timespecs starting with a four-digit number that
+// may be read as a year as well as a time, as in "1800".
+var leadingYear = regexp.MustCompile(`^\s*\d{4}(\s|$)`)
+
+// ParseAll returns all plausible interpretations of timespec, the most
+// likely first.  The first interpretation is the one Parse returns, if
+// any.  More than one interpretation means timespec is ambiguous.
+//
+// The only ambiguity recognized is a leading four-digit number, which
+// may be a time on the 24-hour clock or a year, as in "1800".  The
+// names of months and days of the week do not overlap.
+//
+// An error is only returned if there is no interpretation at all, in
+// which case it is the error returned by Parse.
+func ParseAll(timespec string) ([]*Timespec, error) {
+	return defaultParser.ParseAll(timespec)
+}
+
+// ParseAll is like the package level ParseAll, but honors the options p
+// has been configured with.
+func (p *Parser) ParseAll(timespec string) ([]*Timespec, error) {
+	interpretations := []*Timespec{}
+
+	spec, err := parse(p, timespec)
+	if err == nil {
+		interpretations = append(interpretations, spec)
+	}
+
+	if leadingYear.MatchString(timespec) {
+		// read as in "in 1800", provided the rest fits as well
+		year, diagnostics, yearErr := p.ParseWithDiagnostics("in " + timespec)
+		if yearErr == nil && len(diagnostics) == 0 {
+			interpretations = append(interpretations, year)
+		}
+	}
+
+	if len(interpretations) == 0 {
+		return nil, err
+	}
+
+	return interpretations, nil
+}
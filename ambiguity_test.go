@@ -0,0 +1,65 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAll(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected []time.Time
+	}{
+		{"1800", []time.Time{
+			time.Date(2015, 3, 2, 18, 0, 0, 0, time.UTC),
+			time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"1800 + 1 day", []time.Time{
+			time.Date(2015, 3, 3, 18, 0, 0, 0, time.UTC),
+			time.Date(1800, 1, 2, 0, 0, 0, 0, time.UTC),
+		}},
+		{"1890", []time.Time{
+			time.Date(1890, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		{"1800 Feb 12, 2015", []time.Time{
+			time.Date(2015, 2, 12, 18, 0, 0, 0, time.UTC),
+		}},
+		{"18:00", []time.Time{
+			time.Date(2015, 3, 2, 18, 0, 0, 0, time.UTC),
+		}},
+		{"Mon", []time.Time{
+			time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7),
+		}},
+		{"Mar 02", []time.Time{
+			time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC),
+		}},
+	} {
+		specs, err := ParseAll(testcase.input)
+		if err != nil {
+			t.Errorf("ParseAll(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if len(specs) != len(testcase.expected) {
+			t.Errorf("ParseAll(%q): expected %d interpretations, got %v", testcase.input, len(testcase.expected), specs)
+			continue
+		}
+
+		for i, spec := range specs {
+			if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected[i]) {
+				t.Errorf("ParseAll(%q)[%d].Resolve(now): expected %s, got %s",
+					testcase.input, i, testcase.expected[i], resolved)
+			}
+		}
+	}
+}
+
+func TestParseAll_invalid(t *testing.T) {
+	if specs, err := ParseAll("gibberish"); err == nil {
+		t.Errorf("ParseAll(%q): expected an error, got %v", "gibberish", specs)
+	} else if _, ok := err.(*ParseError); !ok {
+		t.Errorf("ParseAll(%q): expected a *ParseError, got %#v", "gibberish", err)
+	}
+}
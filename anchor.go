@@ -0,0 +1,64 @@
+package timespec
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// WithAnchors makes the parser accept increments relative to the named
+// points in time in anchors instead of now, as in "+ 30 minutes after
+// lunch" or "+ 1 hour before standup".  Names are matched exactly.
+func WithAnchors(anchors map[string]time.Time) Option {
+	return func(p *Parser) {
+		p.anchors = anchors
+	}
+}
+
+// parseAnchored parses an increment followed by "after" or "before" and
+// the name of an anchor.
+func parseAnchored(in io.ByteScanner, spec *Timespec) error {
+	if err := parseincrement(in, spec); err != nil {
+		return err
+	}
+
+	buf := []byte{}
+	skip(in, isspace)
+	any(in, &buf, isalpha)
+
+	switch string(buf) {
+	case "after":
+	case "before":
+		spec.increments = -spec.increments
+	default:
+		return fmt.Errorf("anchor: expected \"after\" or \"before\", got %q", buf)
+	}
+
+	buf = buf[:0]
+	skip(in, isspace)
+	any(in, &buf, nospace)
+
+	if _, ok := spec.options().anchors[string(buf)]; !ok {
+		return fmt.Errorf("anchor: unknown anchor %q", buf)
+	}
+
+	spec.isNow = true
+	spec.anchor = string(buf)
+
+	return nil
+}
+
+// formatAnchored renders the increment of d relative to its anchor.
+func (d *Timespec) formatAnchored() string {
+	count, relation := d.increments, "after"
+	if count < 0 {
+		count, relation = -count, "before"
+	}
+
+	unit := periodWords[d.unit]
+	if count != 1 {
+		unit = plural(unit)
+	}
+
+	return fmt.Sprintf("+ %d %s %s %s", count, unit, relation, d.anchor)
+}
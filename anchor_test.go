@@ -0,0 +1,53 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParser_WithAnchors(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+	lunch := time.Date(2015, 3, 2, 12, 15, 0, 0, time.UTC)
+	parser := NewParser(WithAnchors(map[string]time.Time{
+		"lunch":       lunch,
+		"meeting_end": time.Date(2015, 3, 4, 16, 0, 0, 0, time.UTC),
+	}))
+
+	for _, testcase := range []struct {
+		input     string
+		expected  time.Time
+		canonical string
+	}{
+		{"+ 30 minutes after lunch", lunch.Add(30 * time.Minute), "+ 30 minutes after lunch"},
+		{"+1 hour before lunch", lunch.Add(-time.Hour), "+ 1 hour before lunch"},
+		{"+ 2 days after meeting_end", time.Date(2015, 3, 6, 16, 0, 0, 0, time.UTC), "+ 2 days after meeting_end"},
+		{"noon tomorrow", time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC), "12:00 tomorrow"},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.String(); actual != testcase.canonical {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", testcase.input, testcase.canonical, actual)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"+ 30 minutes after dinner", "+ 30 minutes during lunch", "+ 30 minutes"} {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func TestParser_WithAnchors_rejectedByDefault(t *testing.T) {
+	if _, err := Parse("+ 30 minutes after lunch"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "+ 30 minutes after lunch")
+	}
+}
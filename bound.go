@@ -0,0 +1,67 @@
+package timespec
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A BoundKind tells which side of the point in time returned by
+// ParseBound is meant.
+type BoundKind int
+
+const (
+	// Exact refers to the point in time itself.
+	Exact BoundKind = iota
+	// After refers to the times following it, as in "after 5pm".
+	After
+	// Before refers to the times preceding it, as in "before noon".
+	Before
+)
+
+// String returns "exact", "after" or "before".
+func (k BoundKind) String() string {
+	switch k {
+	case After:
+		return "after"
+	case Before:
+		return "before"
+	}
+
+	return "exact"
+}
+
+// boundPrefix matches "after" or "before" starting a bound, ignoring
+// case.
+var boundPrefix = regexp.MustCompile(`^(?i)\s*(after|before)\s+`)
+
+// ParseBound parses an open-ended bound such as "after 5pm" or "before
+// noon tomorrow", returning the spec for the boundary along with the
+// side of it that is meant.  A timespec without "after" or "before" is
+// an Exact bound.
+//
+// If an error is returned, it is of type *ParseError and refers to a
+// position in s.
+func ParseBound(s string) (*Timespec, BoundKind, error) {
+	return defaultParser.ParseBound(s)
+}
+
+// ParseBound is like the package level ParseBound, but honors the
+// options p has been configured with.
+func (p *Parser) ParseBound(s string) (*Timespec, BoundKind, error) {
+	kind, start := Exact, 0
+
+	if match := boundPrefix.FindStringSubmatch(s); match != nil {
+		kind, start = After, len(match[0])
+		if strings.EqualFold(match[1], "before") {
+			kind = Before
+		}
+	}
+
+	spec, err := parse(p, s[start:])
+	if err != nil {
+		perr := err.(*ParseError)
+		return nil, kind, &ParseError{Src: s, Pos: start + perr.Pos, Msg: perr.Msg}
+	}
+
+	return spec, kind, nil
+}
@@ -0,0 +1,50 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBound(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		kind     BoundKind
+		expected time.Time
+	}{
+		{"after 5pm", After, time.Date(2015, 2, 12, 17, 0, 0, 0, time.UTC)},
+		{"before noon", Before, time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"noon", Exact, time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"  After   noon tomorrow", After, time.Date(2015, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{"BEFORE now + 1 hour", Before, time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, kind, err := ParseBound(testcase.input)
+		if err != nil {
+			t.Errorf("ParseBound(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if kind != testcase.kind {
+			t.Errorf("ParseBound(%q): expected kind %s, got %s", testcase.input, testcase.kind, kind)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("ParseBound(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParseBound_error(t *testing.T) {
+	_, _, err := ParseBound("after 25:00")
+
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("ParseBound(%q): expected a *ParseError, got %#v", "after 25:00", err)
+	}
+
+	if perr.Src != "after 25:00" || perr.Pos < len("after ") {
+		t.Errorf("ParseBound(%q): expected an error within the time, got %s", "after 25:00", perr)
+	}
+}
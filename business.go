@@ -0,0 +1,185 @@
+package timespec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// defaultWeekend holds the days skipped by business day computations
+// unless configured otherwise with WithWeekend.
+var defaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// WithWeekend sets the days of the week that are not business days.
+// The default is Saturday and Sunday.
+func WithWeekend(days ...time.Weekday) Option {
+	return func(p *Parser) {
+		p.weekend = days
+	}
+}
+
+// BusinessDaysOnly makes Resolve count day increments in business days,
+// skipping the days set with WithWeekend: "now + 3 days" on a Thursday
+// resolves to the following Tuesday.  Week and fortnight increments
+// advance by as many business days as there are in a week or fortnight
+// respectively, so that "now + 1 week" keeps the day of the week if that
+// is a business day.
+func BusinessDaysOnly() Option {
+	return func(p *Parser) {
+		p.businessDaysOnly = true
+	}
+}
+
+// Business hours used unless configured otherwise with
+// WithBusinessHours.
+const (
+	defaultBusinessOpen  = 9 * time.Hour
+	defaultBusinessClose = 17 * time.Hour
+)
+
+// WithBusinessHours sets the times of day, as offsets from midnight,
+// "start of business day" and "end of business day" refer to.  The
+// default is 9:00 to 17:00.
+func WithBusinessHours(open, close time.Duration) Option {
+	return func(p *Parser) {
+		p.businessHoursSet = true
+		p.businessOpen = open
+		p.businessClose = close
+	}
+}
+
+// businessHours returns the opening and closing times of day set with
+// WithBusinessHours.
+func (p *Parser) businessHours() (open, close time.Duration) {
+	if !p.businessHoursSet {
+		return defaultBusinessOpen, defaultBusinessClose
+	}
+
+	return p.businessOpen, p.businessClose
+}
+
+// setBusinessTime sets the time of spec to the start or end of the
+// business day, depending on edge.
+func setBusinessTime(spec *Timespec, edge edgeType) {
+	open, close := spec.options().businessHours()
+
+	at := close
+	if edge == edgeBeginning {
+		at = open
+	}
+
+	setClock(spec, at)
+}
+
+// parseBusinessAbbreviation parses "SOD" and "EOD", ignoring case, as
+// abbreviations for "start of business day" and "end of business day".
+func parseBusinessAbbreviation(in io.ByteScanner, spec *Timespec) error {
+	buf := []byte{}
+	any(in, &buf, isalpha)
+
+	switch strings.ToUpper(string(buf)) {
+	case "SOD":
+		setBusinessTime(spec, edgeBeginning)
+	case "EOD":
+		setBusinessTime(spec, edgeEnd)
+	default:
+		return fmt.Errorf("business: expected \"SOD\" or \"EOD\", got %q", buf)
+	}
+
+	return nil
+}
+
+// isWeekend reports whether day is not a business day.
+func (p *Parser) isWeekend(day time.Weekday) bool {
+	weekend := p.weekend
+	if weekend == nil {
+		weekend = defaultWeekend
+	}
+
+	for _, w := range weekend {
+		if w == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// businessDaysPerWeek returns the number of days of the week that are
+// not weekend days.
+func (p *Parser) businessDaysPerWeek() int {
+	perWeek := 0
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !p.isWeekend(day) {
+			perWeek++
+		}
+	}
+
+	return perWeek
+}
+
+// NextBusinessDays returns the first n business days starting with the
+// date d resolves to, which is included if it is a business day itself.
+// Every returned time has the time of day of Resolve(now).
+//
+// Which days are business days is determined by the WithWeekend option
+// d has been parsed with.  If every day is a weekend day, the result is
+// nil.
+func (d *Timespec) NextBusinessDays(now time.Time, n int) []time.Time {
+	options := d.options()
+	if options.businessDaysPerWeek() == 0 {
+		return nil
+	}
+
+	days := []time.Time{}
+
+	for t := d.Resolve(now); len(days) < n; t = t.AddDate(0, 0, 1) {
+		if !options.isWeekend(t.Weekday()) {
+			days = append(days, t)
+		}
+	}
+
+	return days
+}
+
+// addBusinessDays adds count business days, weeks or fortnights to the
+// date of d, and reports false for other units or if there are no
+// business days.
+func (d *Timespec) addBusinessDays(count int, unit Period) bool {
+	options := d.options()
+	perWeek := options.businessDaysPerWeek()
+
+	var n int
+	switch unit {
+	case Days:
+		n = count
+	case Weeks:
+		n = perWeek * count
+	case Fortnights:
+		n = 2 * perWeek * count
+	default:
+		return false
+	}
+
+	if perWeek == 0 {
+		return false
+	}
+
+	step := 1
+	if n < 0 {
+		n, step = -n, -1
+	}
+
+	date := time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+	for n > 0 {
+		date = date.AddDate(0, 0, step)
+		if !options.isWeekend(date.Weekday()) {
+			n--
+		}
+	}
+
+	d.year, d.month, d.day = date.Date()
+
+	return true
+}
@@ -0,0 +1,154 @@
+package timespec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimespec_NextBusinessDays(t *testing.T) {
+	// a Thursday
+	now := time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		parser   *Parser
+		expected []time.Time
+	}{
+		{NewParser(), []time.Time{
+			time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 1, 29, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 2, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 2, 2, 9, 0, 0, 0, time.UTC),
+		}},
+		{NewParser(WithWeekend(time.Friday, time.Saturday)), []time.Time{
+			time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 1, 31, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 2, 1, 9, 0, 0, 0, time.UTC),
+			time.Date(2010, 2, 2, 9, 0, 0, 0, time.UTC),
+		}},
+	} {
+		spec, err := testcase.parser.Parse("now")
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", "now", err)
+		}
+
+		if days := spec.NextBusinessDays(now, 4); !reflect.DeepEqual(days, testcase.expected) {
+			t.Errorf("NextBusinessDays(now, 4) with weekend %v:\n  Expected: %v\n       Got: %v",
+				testcase.parser.weekend, testcase.expected, days)
+		}
+	}
+}
+
+func TestTimespec_NextBusinessDays_startsOnWeekend(t *testing.T) {
+	now := time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC)
+	expected := []time.Time{
+		time.Date(2010, 2, 1, 14, 0, 0, 0, time.UTC),
+		time.Date(2010, 2, 2, 14, 0, 0, 0, time.UTC),
+	}
+
+	spec, err := Parse("14:00 Jan 30, 2010")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00 Jan 30, 2010", err)
+	}
+
+	if days := spec.NextBusinessDays(now, 2); !reflect.DeepEqual(days, expected) {
+		t.Errorf("NextBusinessDays(now, 2):\n  Expected: %v\n       Got: %v", expected, days)
+	}
+}
+
+func TestTimespec_NextBusinessDays_allWeekend(t *testing.T) {
+	now := time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC)
+	allWeek := WithWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+
+	spec, err := NewParser(allWeek).Parse("14:00")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00", err)
+	}
+
+	if days := spec.NextBusinessDays(now, 2); days != nil {
+		t.Errorf("NextBusinessDays(now, 2): expected nil, got %v", days)
+	}
+}
+
+func TestParser_BusinessDaysOnly(t *testing.T) {
+	// a Thursday
+	now := time.Date(2010, 1, 28, 9, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		parser   *Parser
+		input    string
+		expected time.Time
+	}{
+		{NewParser(), "now + 3 days", time.Date(2010, 1, 31, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 3 days", time.Date(2010, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 1 day", time.Date(2010, 1, 29, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 1 week", time.Date(2010, 2, 4, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 1 fortnight", time.Date(2010, 2, 11, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 2 hours", time.Date(2010, 1, 28, 11, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "noon Jan 30, 2010 + 1 day", time.Date(2010, 2, 1, 12, 0, 0, 0, time.UTC)},
+		// chained increments are applied one after the other
+		{NewParser(BusinessDaysOnly()), "now + 1 day + 2 hours", time.Date(2010, 1, 29, 11, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 1 day + 1 day", time.Date(2010, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 2 hours + 1 day", time.Date(2010, 1, 29, 11, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly()), "now + 1 week + 1 day", time.Date(2010, 2, 5, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly(), WithWeekend(time.Friday, time.Saturday)), "now + 3 days",
+			time.Date(2010, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{NewParser(BusinessDaysOnly(), WithWeekend(time.Friday, time.Saturday)), "now + 1 day",
+			time.Date(2010, 1, 31, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := testcase.parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now) with weekend %v: expected %s, got %s",
+				testcase.input, testcase.parser.weekend, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParse_businessDay(t *testing.T) {
+	// a Thursday
+	now := time.Date(2010, 1, 28, 9, 30, 0, 0, time.UTC)
+	custom := NewParser(WithBusinessHours(8*time.Hour+30*time.Minute, 18*time.Hour))
+
+	for _, testcase := range []struct {
+		parser   *Parser
+		input    string
+		expected time.Time
+	}{
+		{defaultParser, "EOD tomorrow", time.Date(2010, 1, 29, 17, 0, 0, 0, time.UTC)},
+		{defaultParser, "SOD Monday", time.Date(2010, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{defaultParser, "eod", time.Date(2010, 1, 28, 17, 0, 0, 0, time.UTC)},
+		{defaultParser, "end of business day", time.Date(2010, 1, 28, 17, 0, 0, 0, time.UTC)},
+		{defaultParser, "start of business day Feb 12", time.Date(2010, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{defaultParser, "tomorrow EOD", time.Date(2010, 1, 29, 17, 0, 0, 0, time.UTC)},
+		{defaultParser, "Monday start of business day", time.Date(2010, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{defaultParser, "EOD + 2 days", time.Date(2010, 1, 30, 17, 0, 0, 0, time.UTC)},
+		{custom, "EOD tomorrow", time.Date(2010, 1, 29, 18, 0, 0, 0, time.UTC)},
+		{custom, "SOD Monday", time.Date(2010, 2, 1, 8, 30, 0, 0, time.UTC)},
+	} {
+		spec, diagnostics, err := testcase.parser.ParseWithDiagnostics(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if len(diagnostics) != 0 {
+			t.Errorf("Parse(%q): expected no diagnostics, got %v", testcase.input, diagnostics)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"start of day", "end of business week", "EODtomorrow"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package timespec
+
+import (
+	"container/list"
+	"sync"
+)
+
+// A ParserCache parses timespecs using a Parser and remembers the
+// results for a bounded number of recently used inputs.  It is safe for
+// concurrent use.
+//
+// Every call to Parse returns a fresh copy of the cached spec, sharing
+// nothing with it, so that callers may modify the spec they get, for
+// example by unmarshaling into it, without affecting the cache.
+type ParserCache struct {
+	parser *Parser
+	size   int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	input string
+	spec  *Timespec
+}
+
+// NewParserCache returns a cache holding at most size parsed specs.
+// Parsing is delegated to parser; a nil parser parses like the package
+// level Parse function.
+func NewParserCache(parser *Parser, size int) *ParserCache {
+	return &ParserCache{
+		parser:  parser,
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Parse parses a timespec, returning a copy of the cached result if the
+// same input has been parsed recently.  Inputs that fail to parse are
+// not cached.
+//
+// If an error is returned, it is of type *ParseError.
+func (c *ParserCache) Parse(timespec string) (*Timespec, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[timespec]; ok {
+		c.order.MoveToFront(element)
+		spec := element.Value.(*cacheEntry).spec.clone()
+		c.mu.Unlock()
+		return spec, nil
+	}
+	c.mu.Unlock()
+
+	spec, err := parse(c.parser, timespec)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[timespec]; !ok && c.size > 0 {
+		c.entries[timespec] = c.order.PushFront(&cacheEntry{input: timespec, spec: spec.clone()})
+
+		for c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).input)
+		}
+	}
+
+	return spec, nil
+}
+
+// Len returns the number of specs currently held by the cache.
+func (c *ParserCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
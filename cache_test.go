@@ -0,0 +1,112 @@
+package timespec
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParserCache_Parse_hit(t *testing.T) {
+	cache := NewParserCache(nil, 2)
+
+	first, err := cache.Parse("now + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "now + 1 day", err)
+	}
+
+	second, err := cache.Parse("now + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "now + 1 day", err)
+	}
+
+	if first == second {
+		t.Fatalf("Parse(%q): expected distinct copies on cache hit", "now + 1 day")
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("Parse(%q):\n  Expected: %#v\n       Got: %#v", "now + 1 day", first, second)
+	}
+
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+	expected := time.Date(2010, 1, 2, 15, 10, 0, 0, time.UTC)
+	first.Resolve(now)
+
+	third, _ := cache.Parse("now + 1 day")
+	if resolved := third.Resolve(now); !resolved.Equal(expected) {
+		t.Errorf("Resolve(now) after resolving a cached copy: expected %s, got %s", expected, resolved)
+	}
+}
+
+func TestParserCache_Parse_sharesNothing(t *testing.T) {
+	const input = "noon + 1 day + 2 hours foo"
+	cache := NewParserCache(nil, 2)
+
+	first, err := cache.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", input, err)
+	}
+	if len(first.more) == 0 || len(first.diagnostics) == 0 || len(first.errors) == 0 {
+		t.Fatalf("Parse(%q): expected chained increments, diagnostics and errors, got %#v", input, first)
+	}
+
+	second, _ := cache.Parse(input)
+	second.more[0] = Increment{Count: 5, Unit: Years}
+	second.diagnostics[0].Msg = "changed"
+	second.errors[0].Msg = "changed"
+
+	third, _ := cache.Parse(input)
+	for _, spec := range []*Timespec{first, third} {
+		if spec.more[0] != (Increment{Count: 2, Unit: Hours}) || spec.diagnostics[0].Msg == "changed" || spec.errors[0].Msg == "changed" {
+			t.Errorf("Parse(%q): modifying a copy changed %#v", input, spec)
+		}
+	}
+}
+
+func TestParserCache_Parse_evicts(t *testing.T) {
+	cache := NewParserCache(nil, 2)
+
+	for _, input := range []string{"11:00", "12:00", "13:00", "14:00", "12:00"} {
+		cache.Parse(input)
+	}
+
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len(): expected 2, got %d", n)
+	}
+}
+
+func TestParserCache_Parse_error(t *testing.T) {
+	cache := NewParserCache(nil, 2)
+
+	if _, err := cache.Parse("florble"); err == nil {
+		t.Fatalf("Parse(%q): expected an error", "florble")
+	}
+
+	if n := cache.Len(); n != 0 {
+		t.Errorf("Len(): expected errors not to be cached, got %d entries", n)
+	}
+}
+
+func TestParserCache_Parse_concurrent(t *testing.T) {
+	cache := NewParserCache(NewParser(), 3)
+	inputs := []string{"now + 1 day", "12:00", "14:00 Feb 12, 2015", "10 am next week"}
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				input := inputs[(i+j)%len(inputs)]
+				spec, err := cache.Parse(input)
+				if err != nil {
+					t.Errorf("Parse(%q): %s", input, err)
+					return
+				}
+				spec.Resolve(now)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
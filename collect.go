@@ -0,0 +1,72 @@
+package timespec
+
+import "io"
+
+// ParseCollect is like Parse, but rather than stopping at the first
+// problem it returns every error it has recovered from, along with the
+// spec made up of the remaining parts of the timespec.
+//
+// An invalid date, increment or timezone is recoverable: the offending
+// part is skipped and parsing continues with the next one, as does
+// trailing input.  Any other error, such as an invalid time, ends parsing;
+// in that case the spec is nil and the error is the only one returned.
+// All errors refer to positions in s.
+func ParseCollect(s string) (*Timespec, []*ParseError) {
+	return defaultParser.ParseCollect(s)
+}
+
+// ParseCollect is like the package level ParseCollect, but honors the
+// options p has been configured with, other than Strict.
+func (p *Parser) ParseCollect(s string) (*Timespec, []*ParseError) {
+	collecting := *p
+	collecting.strict, collecting.collect = false, true
+
+	spec, err := parse(&collecting, s)
+	if err != nil {
+		return nil, []*ParseError{err.(*ParseError)}
+	}
+
+	errs := []*ParseError{}
+	for _, e := range spec.errors {
+		errs = append(errs, p.parseError(s, e.Pos, e.Msg))
+	}
+
+	spec.parser, spec.errors = p, nil
+
+	return spec, errs
+}
+
+// ignore records err for ParseCollect, along with a diagnostic telling
+// that what has been ignored.
+func ignore(in io.ByteScanner, spec *Timespec, what string, err error) {
+	warn(in, spec, "ignored %s: %s", what, err)
+	spec.errors = append(spec.errors, Diagnostic{Pos: offset(in), Msg: err.Error()})
+}
+
+// skipIgnored moves in past the rest of the word an ignored production
+// starting at start failed in, so that what is left of it, such as the
+// "zz" of "now zzz", is not reported again as trailing input.  With
+// AllowPayload in moves back to start instead, leaving the word for the
+// payload.
+func skipIgnored(in io.ByteScanner, spec *Timespec, start int) {
+	if spec.options().payload {
+		rewind(in, start)
+		return
+	}
+
+	any(in, &[]byte{}, nospace)
+}
+
+// skipToIncrement skips the words preceding the next increment, so that
+// ParseCollect can carry on after an invalid date.
+func skipToIncrement(in io.ByteScanner) {
+	for {
+		c := skip(in, isspace)
+		if c == 0 || c == '+' || c == '-' || lookingAtWord(in, "next") {
+			return
+		}
+
+		word := []byte{}
+		any(in, &word, func(c byte) bool { return !isspace(c) })
+	}
+}
@@ -0,0 +1,83 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCollect(t *testing.T) {
+	input := "noon Fbr 12 + 3 minits UTQ"
+
+	spec, errs := ParseCollect(input)
+	if spec == nil {
+		t.Fatalf("ParseCollect(%q): expected a spec", input)
+	}
+
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	if actual, expected := spec.Resolve(now), time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("ParseCollect(%q).Resolve(now): expected %s, got %s", input, expected, actual)
+	}
+
+	expected := []struct {
+		pos int
+		msg string
+	}{
+		{8, `date: invalid month name: "Fbr"`},
+		{22, `period: invalid period: "minits"`},
+		{26, `timezone: invalid timezone: "UTQ"`},
+	}
+
+	if len(errs) != len(expected) {
+		t.Fatalf("ParseCollect(%q): expected %d errors, got %v", input, len(expected), errs)
+	}
+
+	for i, err := range errs {
+		if err.Src != input || err.Pos != expected[i].pos || err.Msg != expected[i].msg {
+			t.Errorf("ParseCollect(%q): expected error %d at %d: %s, got %s",
+				input, i, expected[i].pos, expected[i].msg, err)
+		}
+	}
+}
+
+func TestParseCollect_clippedWord(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{"now zzz", 5, `increment: expected '+' or '-', got 'z'`},
+		{"12 noon", 4, `increment: expected "next", got "no"`},
+		{"now + 1 day garbage", 12, `timespec: unexpected trailing input "garbage"`},
+	} {
+		_, errs := ParseCollect(testcase.input)
+		if len(errs) != 1 || errs[0].Pos != testcase.pos || errs[0].Msg != testcase.msg {
+			t.Errorf("ParseCollect(%q): expected a single error at %d: %s, got %v",
+				testcase.input, testcase.pos, testcase.msg, errs)
+		}
+	}
+}
+
+func TestParseCollect_valid(t *testing.T) {
+	spec, errs := ParseCollect("noon tomorrow + 1 hour")
+	if spec == nil || len(errs) != 0 {
+		t.Errorf("ParseCollect(%q): expected a spec without errors, got %v, %v", "noon tomorrow + 1 hour", spec, errs)
+	}
+}
+
+func TestParseCollect_unrecoverable(t *testing.T) {
+	spec, errs := ParseCollect("25:00 Fbr")
+	if spec != nil {
+		t.Errorf("ParseCollect(%q): expected no spec, got %s", "25:00 Fbr", spec)
+	}
+
+	if len(errs) != 1 || errs[0].Msg != "clock: invalid hours: 25" {
+		t.Errorf("ParseCollect(%q): expected a single error for the hours, got %v", "25:00 Fbr", errs)
+	}
+}
+
+func TestParser_ParseCollect_strict(t *testing.T) {
+	_, errs := NewParser(Strict()).ParseCollect("noon Fbr 12")
+	if len(errs) != 1 || errs[0].Pos != 8 {
+		t.Errorf("ParseCollect(%q): expected an error at 8, got %v", "noon Fbr 12", errs)
+	}
+}
@@ -0,0 +1,238 @@
+package timespec
+
+import (
+	"fmt"
+	"time"
+)
+
+// A TimeOfDay is the clock portion of a timespec, independent of any
+// date.
+type TimeOfDay struct {
+	Hours   int
+	Minutes int
+	Seconds int
+}
+
+// String returns the time of day in 24-hour "HH:MM" format.  Seconds are
+// appended as ":SS" if they are not zero.
+func (t TimeOfDay) String() string {
+	if t.Seconds != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", t.Hours, t.Minutes, t.Seconds)
+	}
+
+	return fmt.Sprintf("%02d:%02d", t.Hours, t.Minutes)
+}
+
+// TimeOfDay returns the time of day specified in d.  The boolean result
+// is false if d does not specify a time, as is the case for "now".
+func (d *Timespec) TimeOfDay() (TimeOfDay, bool) {
+	if d.isNow {
+		return TimeOfDay{}, false
+	}
+
+	return TimeOfDay{Hours: d.hours, Minutes: d.minutes, Seconds: d.seconds}, true
+}
+
+// Increment returns the increment specified in d as a count of periods.
+// The boolean result is false if d does not specify an increment.
+func (d *Timespec) Increment() (count int, unit Period, ok bool) {
+	if !d.HasIncrement() {
+		return 0, 0, false
+	}
+
+	return d.increments, d.unit, true
+}
+
+// HasIncrement reports whether d specifies an increment.  An increment
+// of zero, as in "now + 0 days", counts as one.
+func (d *Timespec) HasIncrement() bool {
+	return d.hasIncrement || d.increments != 0
+}
+
+// HasDate reports whether d names a date, as "tomorrow", "Friday" and
+// "Feb 12" do.  Specs taking the date from the time they are resolved
+// against, such as "noon", "now" or "noon today", do not.
+func (d *Timespec) HasDate() bool {
+	return !d.instant.IsZero() || !d.isToday() || d.isTomorrow || d.isYesterday || d.dayOffset != 0
+}
+
+// IsNow reports whether d is relative to the current time, as "now" and
+// "now + 1 hour" are, rather than naming a time of day.
+func (d *Timespec) IsNow() bool {
+	return d.isNow
+}
+
+// Clock returns the time of day specified in d, the same as the fields
+// of TimeOfDay.  It is midnight for a date without a time, such as
+// "tomorrow", and all zero for "now".
+func (d *Timespec) Clock() (hours, minutes, seconds int) {
+	t, _ := d.TimeOfDay()
+
+	return t.Hours, t.Minutes, t.Seconds
+}
+
+// NeedsYearInference reports whether resolving d picks the year based on
+// the time it is resolved against, as for "Feb 12", which names a month
+// and day but no year.  Results for such specs may change when the year
+// does, while those for "Feb 12, 2015" do not.
+func (d *Timespec) NeedsYearInference() bool {
+	return d.instant.IsZero() && !d.isNow && !d.isWeekday && d.year == 0 && d.month != 0
+}
+
+// Location returns the location of the timezone given in d, which its
+// date and time are read in when resolving.  It is time.UTC for "UTC"
+// and "GMT", and nil if d does not specify a timezone.
+func (d *Timespec) Location() *time.Location {
+	if d.location == nil && d.zone != "" {
+		return time.UTC
+	}
+
+	return d.location
+}
+
+// ExtractIncrement parses the timespec s and returns its increment, as
+// in "+ 1 day" for "now + 1 day".  The boolean result is false if s
+// does not specify an increment.
+//
+// If an error is returned, it is of type *ParseError.
+func ExtractIncrement(s string) (count int, unit Period, ok bool, err error) {
+	spec, err := Parse(s)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	count, unit, ok = spec.Increment()
+	return count, unit, ok, nil
+}
+
+// SplitDateTime decomposes d into a spec carrying only its date and a
+// spec carrying only its time.  Increments are part of neither.
+//
+// The date part of "now" is the current date, its time part is "now"
+// itself, which resolves to the current time.  "tomorrow" belongs to the
+// date part.
+func (d *Timespec) SplitDateTime() (datePart, timePart *Timespec) {
+	datePart = &Timespec{
+		year:        d.year,
+		month:       d.month,
+		day:         d.day,
+		isTomorrow:  d.isTomorrow,
+		isYesterday: d.isYesterday,
+		dayOffset:   d.dayOffset,
+		isWeekday:   d.isWeekday,
+		weekday:     d.weekday,
+		lastWeekday: d.lastWeekday,
+		nextWeekday: d.nextWeekday,
+		thisWeekday: d.thisWeekday,
+		thisPeriod:  d.thisPeriod,
+		thisUnit:    d.thisUnit,
+		ordinal:     d.ordinal,
+		dateOnly:    true,
+		parser:      d.parser,
+	}
+	timePart = &Timespec{
+		hours:    d.hours,
+		minutes:  d.minutes,
+		seconds:  d.seconds,
+		isNow:    d.isNow,
+		endOfDay: d.endOfDay,
+		edge:     d.edge,
+		edgeUnit: d.edgeUnit,
+		fiscal:   d.fiscal,
+		parser:   d.parser,
+	}
+
+	if !d.instant.IsZero() {
+		t := d.instant.UTC()
+		datePart.year, datePart.month, datePart.day = t.Date()
+		timePart.hours, timePart.minutes, timePart.seconds = t.Clock()
+	}
+
+	return datePart, timePart
+}
+
+// Merge combines the date of datePart and the time of timePart into a
+// single spec, as when they are entered separately.  Merging the parts
+// returned by SplitDateTime undoes the split, except for the increment,
+// which neither part carries.
+//
+// The date is always taken from datePart and the time from timePart,
+// whatever else each of them specifies: merging "Feb 12 10:00" and "9am"
+// yields "09:00 Feb 12".  The increment and timezone of timePart take
+// precedence over those of datePart, which are used if timePart has
+// none.  As "now" in timePart refers to the current date as well as the
+// current time, only a relative date such as "tomorrow" is kept with it.
+func Merge(datePart, timePart *Timespec) *Timespec {
+	merged, _ := datePart.SplitDateTime()
+	_, clock := timePart.SplitDateTime()
+
+	merged.isTonight = datePart.isTonight
+	merged.hours, merged.minutes, merged.seconds = clock.hours, clock.minutes, clock.seconds
+	merged.isNow = clock.isNow
+	merged.endOfDay = clock.endOfDay
+	merged.edge, merged.edgeUnit = clock.edge, clock.edgeUnit
+	merged.fiscal = clock.fiscal
+	merged.dateOnly = timePart.dateOnly && timePart.instant.IsZero()
+
+	increment := datePart
+	if timePart.HasIncrement() {
+		increment = timePart
+	}
+	merged.increments, merged.unit = increment.increments, increment.unit
+	merged.hasIncrement = increment.hasIncrement
+	merged.more = increment.more
+
+	zone := datePart
+	if timePart.zone != "" {
+		zone = timePart
+	}
+	merged.zone, merged.location = zone.zone, zone.location
+	merged.namedZone = zone.namedZone
+
+	return merged
+}
+
+// An Increment is a count of periods added to a point in time.
+type Increment struct {
+	Count int
+	Unit  Period
+}
+
+// Components is a snapshot of the parts of a timespec.  Fields that
+// have not been given in the timespec are zero.
+type Components struct {
+	Hours   int
+	Minutes int
+	Seconds int
+
+	Month time.Month
+	Day   int
+	Year  int
+
+	IsNow       bool
+	IsTomorrow  bool
+	IsYesterday bool
+
+	Increment Increment
+
+	// Location is the location of the timezone given, as returned by
+	// the Location method.
+	Location *time.Location
+}
+
+// Components returns the parts of d as parsed, before resolving.
+func (d *Timespec) Components() Components {
+	return Components{
+		Hours:       d.hours,
+		Minutes:     d.minutes,
+		Seconds:     d.seconds,
+		Month:       d.month,
+		Day:         d.day,
+		Year:        d.year,
+		IsNow:       d.isNow,
+		IsTomorrow:  d.isTomorrow,
+		IsYesterday: d.isYesterday,
+		Increment:   Increment{Count: d.increments, Unit: d.unit},
+		Location:    d.Location(),
+	}
+}
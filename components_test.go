@@ -0,0 +1,397 @@
+package timespec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimespec_TimeOfDay(t *testing.T) {
+	spec, err := Parse("9:30 am")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "9:30 am", err)
+	}
+
+	timeOfDay, ok := spec.TimeOfDay()
+	if !ok {
+		t.Fatalf("TimeOfDay(): expected a time of day for %q", "9:30 am")
+	}
+
+	if expected := (TimeOfDay{Hours: 9, Minutes: 30}); timeOfDay != expected {
+		t.Errorf("TimeOfDay(): expected %#v, got %#v", expected, timeOfDay)
+	}
+
+	if s := timeOfDay.String(); s != "09:30" {
+		t.Errorf("String(): expected %q, got %q", "09:30", s)
+	}
+}
+
+func TestTimespec_TimeOfDay_now(t *testing.T) {
+	spec, err := Parse("now")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "now", err)
+	}
+
+	if _, ok := spec.TimeOfDay(); ok {
+		t.Errorf("TimeOfDay(): expected no time of day for %q", "now")
+	}
+}
+
+func TestTimeOfDay_String(t *testing.T) {
+	for _, testcase := range []struct {
+		timeOfDay TimeOfDay
+		expected  string
+	}{
+		{TimeOfDay{}, "00:00"},
+		{TimeOfDay{Hours: 14, Minutes: 5}, "14:05"},
+		{TimeOfDay{Hours: 23, Minutes: 59, Seconds: 7}, "23:59:07"},
+	} {
+		if s := testcase.timeOfDay.String(); s != testcase.expected {
+			t.Errorf("%#v.String(): expected %q, got %q", testcase.timeOfDay, testcase.expected, s)
+		}
+	}
+}
+
+func TestTimespec_Increment(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		count int
+		unit  Period
+		ok    bool
+	}{
+		{"now + 3 weeks", 3, Weeks, true},
+		{"now next month", 1, Months, true},
+		{"10:00 + 20 minutes", 20, Minutes, true},
+		{"noon", 0, 0, false},
+		{"now + 0 days", 0, Days, true},
+		{"10:00 + 0 minutes", 0, Minutes, true},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", testcase.input, err)
+		}
+
+		count, unit, ok := spec.Increment()
+		if count != testcase.count || unit != testcase.unit || ok != testcase.ok {
+			t.Errorf("Parse(%q).Increment(): expected (%d, %d, %v), got (%d, %d, %v)",
+				testcase.input, testcase.count, testcase.unit, testcase.ok, count, unit, ok)
+		}
+	}
+}
+
+func TestTimespec_HasIncrement(t *testing.T) {
+	now := time.Date(2015, 2, 12, 10, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected bool
+	}{
+		{"now + 0 minutes", true},
+		{"10:00 + 0 minutes", true},
+		{"noon tomorrow + 0 days", true},
+		{"now + 5 minutes", true},
+		{"10:00", false},
+		{"now", false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.HasIncrement(); actual != testcase.expected {
+			t.Errorf("Parse(%q).HasIncrement(): expected %v, got %v",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	spec, err := Parse("now + 0 minutes")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "now + 0 minutes", err)
+	}
+
+	if actual := spec.Resolve(now); !actual.Equal(now) {
+		t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", "now + 0 minutes", now, actual)
+	}
+}
+
+func TestTimespec_accessors(t *testing.T) {
+	for _, testcase := range []struct {
+		input     string
+		hasDate   bool
+		isNow     bool
+		timeOfDay TimeOfDay
+		increment Increment
+	}{
+		{"14:30", false, false, TimeOfDay{Hours: 14, Minutes: 30}, Increment{}},
+		{"noon today", false, false, TimeOfDay{Hours: 12}, Increment{}},
+		{"9:15:30 tomorrow", true, false, TimeOfDay{Hours: 9, Minutes: 15, Seconds: 30}, Increment{}},
+		{"Friday", true, false, TimeOfDay{}, Increment{}},
+		{"14:00 Feb 12, 2015", true, false, TimeOfDay{Hours: 14}, Increment{}},
+		{"now + 2 hours", false, true, TimeOfDay{}, Increment{Count: 2, Unit: Hours}},
+		{"noon - 3 days", false, false, TimeOfDay{Hours: 12}, Increment{Count: -3, Unit: Days}},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.HasDate(); actual != testcase.hasDate {
+			t.Errorf("Parse(%q).HasDate(): expected %v, got %v", testcase.input, testcase.hasDate, actual)
+		}
+
+		if actual := spec.IsNow(); actual != testcase.isNow {
+			t.Errorf("Parse(%q).IsNow(): expected %v, got %v", testcase.input, testcase.isNow, actual)
+		}
+
+		if hours, minutes, seconds := spec.Clock(); (TimeOfDay{hours, minutes, seconds}) != testcase.timeOfDay {
+			t.Errorf("Parse(%q).Clock(): expected %s, got %02d:%02d:%02d", testcase.input, testcase.timeOfDay, hours, minutes, seconds)
+		}
+
+		count, unit, ok := spec.Increment()
+		if (Increment{count, unit}) != testcase.increment || ok != (testcase.increment.Count != 0) {
+			t.Errorf("Parse(%q).Increment(): expected %v, got %d %s, %v", testcase.input, testcase.increment, count, unit, ok)
+		}
+	}
+}
+
+func TestTimespec_NeedsYearInference(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected bool
+	}{
+		{"Feb 12", true},
+		{"noon Feb 12 + 1 week", true},
+		{"Feb 12, 2015", false},
+		{"noon", false},
+		{"tomorrow", false},
+		{"Friday", false},
+		{"2015-02-12T10:00:00Z", false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.NeedsYearInference(); actual != testcase.expected {
+			t.Errorf("Parse(%q).NeedsYearInference(): expected %v, got %v",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_SplitDateTime(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input           string
+		date, timeOfDay time.Time
+	}{
+		{
+			"14:00 Feb 12, 2015",
+			time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC),
+			time.Date(2010, 1, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			"9:30 am tomorrow + 1 day",
+			time.Date(2010, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2010, 1, 1, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			"now + 1 week",
+			time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+			now,
+		},
+		{
+			"2015-03-02T14:30:00Z",
+			time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2010, 1, 1, 14, 30, 0, 0, time.UTC),
+		},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", testcase.input, err)
+		}
+
+		datePart, timePart := spec.SplitDateTime()
+
+		if resolved := datePart.Resolve(now); !resolved.Equal(testcase.date) {
+			t.Errorf("Parse(%q): expected date part to resolve to %s, got %s",
+				testcase.input, testcase.date, resolved)
+		}
+
+		if resolved := timePart.Resolve(now); !resolved.Equal(testcase.timeOfDay) {
+			t.Errorf("Parse(%q): expected time part to resolve to %s, got %s",
+				testcase.input, testcase.timeOfDay, resolved)
+		}
+	}
+}
+
+func TestTimespec_SplitDateTime_fields(t *testing.T) {
+	spec, err := Parse("14:00 Feb 12, 2015")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00 Feb 12, 2015", err)
+	}
+
+	datePart, timePart := spec.SplitDateTime()
+
+	if expected := (&Timespec{year: 2015, month: 2, day: 12, dateOnly: true}); !reflect.DeepEqual(datePart, expected) {
+		t.Errorf("date part:\n  Expected: %#v\n       Got: %#v", expected, datePart)
+	}
+
+	if expected := (&Timespec{hours: 14}); !reflect.DeepEqual(timePart, expected) {
+		t.Errorf("time part:\n  Expected: %#v\n       Got: %#v", expected, timePart)
+	}
+}
+
+func TestTimespec_Components(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected Components
+	}{
+		{"now", Components{IsNow: true}},
+		{"now + 3 days", Components{IsNow: true, Increment: Increment{Count: 3, Unit: Days}}},
+		{"14:15 Feb 12, 2015", Components{Hours: 14, Minutes: 15, Month: time.February, Day: 12, Year: 2015}},
+		{"143005", Components{Hours: 14, Minutes: 30, Seconds: 5}},
+		{"noon tomorrow next week", Components{Hours: 12, IsTomorrow: true, Increment: Increment{Count: 1, Unit: Weeks}}},
+		{"9pm Mar 02", Components{Hours: 21, Month: time.March, Day: 2}},
+		{"9pm UTC", Components{Hours: 21, Location: time.UTC}},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Components(); actual != testcase.expected {
+			t.Errorf("Parse(%q).Components():\n  Expected: %+v\n       Got: %+v",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_Location(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		offset   int
+		zoned    bool
+		expected time.Time
+	}{
+		{"14:00", 0, false, time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{"14:00 UTC", 0, true, time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{"14:00 gmt", 0, true, time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{"14:00 +0200", 2 * 60 * 60, true, time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"14:00 UTC-05:00", -5 * 60 * 60, true, time.Date(2015, 2, 12, 19, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		loc := spec.Location()
+		if !testcase.zoned {
+			if loc != nil {
+				t.Errorf("Parse(%q).Location(): expected nil, got %s", testcase.input, loc)
+			}
+		} else if loc == nil {
+			t.Errorf("Parse(%q).Location(): expected a location, got nil", testcase.input)
+		} else if _, offset := time.Date(2015, 2, 12, 0, 0, 0, 0, loc).Zone(); offset != testcase.offset {
+			t.Errorf("Parse(%q).Location(): expected offset %d, got %d", testcase.input, testcase.offset, offset)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Date(2015, 2, 10, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		datePart, timePart string
+		expected           time.Time
+	}{
+		{"Feb 12", "9am", time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"Feb 12, 2016", "14:30", time.Date(2016, 2, 12, 14, 30, 0, 0, time.UTC)},
+		{"10:00 Feb 12", "9am", time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"Feb 12", "9am Mar 03", time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"tomorrow", "noon + 1 hour", time.Date(2015, 2, 11, 13, 0, 0, 0, time.UTC)},
+		{"Feb 12 + 1 day", "9am", time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"Feb 12 + 1 day", "9am + 2 hours", time.Date(2015, 2, 12, 11, 0, 0, 0, time.UTC)},
+		{"Feb 12", "9am UTC+2", time.Date(2015, 2, 12, 7, 0, 0, 0, time.UTC)},
+		{"Friday", "end of day", time.Date(2015, 2, 13, 23, 59, 59, 0, time.UTC)},
+		{"2015-03-02T14:30:00Z", "9am", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"Feb 12", "2015-03-02T14:30:00Z", time.Date(2015, 2, 12, 14, 30, 0, 0, time.UTC)},
+		{"tomorrow", "now", time.Date(2015, 2, 11, 8, 0, 0, 0, time.UTC)},
+	} {
+		datePart, err := Parse(testcase.datePart)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.datePart, err)
+			continue
+		}
+		timePart, err := Parse(testcase.timePart)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.timePart, err)
+			continue
+		}
+
+		if actual := Merge(datePart, timePart).Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Merge(%q, %q).Resolve(now): expected %s, got %s",
+				testcase.datePart, testcase.timePart, testcase.expected, actual)
+		}
+	}
+}
+
+func TestMerge_undoesSplitDateTime(t *testing.T) {
+	now := time.Date(2015, 2, 10, 8, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{"14:00 Feb 12, 2015", "noon tomorrow", "9am Friday", "now", "Feb 12"} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		expected := spec.clone().Resolve(now)
+		if actual := Merge(spec.SplitDateTime()).Resolve(now); !actual.Equal(expected) {
+			t.Errorf("Merge(Parse(%q).SplitDateTime()).Resolve(now): expected %s, got %s", input, expected, actual)
+		}
+	}
+}
+
+func TestExtractIncrement(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		count int
+		unit  Period
+		ok    bool
+	}{
+		{"now + 1 day", 1, Days, true},
+		{"14:00 Feb 12, 2015 + 3 weeks", 3, Weeks, true},
+		{"noon tomorrow next month", 1, Months, true},
+		{"now PT90M", 90, Minutes, true},
+		{"now", 0, 0, false},
+		{"14:00 Feb 12, 2015", 0, 0, false},
+	} {
+		count, unit, ok, err := ExtractIncrement(testcase.input)
+		if err != nil {
+			t.Errorf("ExtractIncrement(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if count != testcase.count || unit != testcase.unit || ok != testcase.ok {
+			t.Errorf("ExtractIncrement(%q): expected (%d, %s, %v), got (%d, %s, %v)",
+				testcase.input, testcase.count, testcase.unit, testcase.ok, count, unit, ok)
+		}
+	}
+
+	if _, _, _, err := ExtractIncrement("gibberish"); err == nil {
+		t.Errorf("ExtractIncrement(%q): expected an error", "gibberish")
+	}
+}
@@ -0,0 +1,231 @@
+package timespec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Describe returns a plain English description of d for showing to
+// users, such as "1 day from now" or "2:00 PM on February 12th, 2015".
+// Unlike String, the result is not meant to be parsed again: times are
+// written on the 12-hour clock, names are spelled out and increments are
+// phrased relative to the point in time they apply to, as in "3 days
+// after noon tomorrow".
+func (d *Timespec) Describe() string {
+	if !d.instant.IsZero() {
+		t := d.instant
+		return fmt.Sprintf("%s on %s %s", describeClock(t.Hour(), t.Minute(), t.Second()),
+			describeDay(t.Month(), t.Day(), t.Year()), t.Format("MST"))
+	}
+
+	base := d.anchor
+	if base == "" {
+		base = d.describeBase()
+	}
+
+	increments := []Increment{}
+	if d.increments != 0 {
+		increments = append(increments, Increment{Count: d.increments, Unit: d.unit})
+	}
+	increments = append(increments, d.more...)
+
+	return describeIncrements(base, increments)
+}
+
+// describeBase describes the time and date of d, without increments.
+func (d *Timespec) describeBase() string {
+	parts := []string{}
+
+	if d.isNow {
+		parts = append(parts, "now")
+	} else if !d.dateOnly {
+		parts = append(parts, d.describeTime())
+		if d.zone != "" {
+			parts = append(parts, d.zone)
+		}
+	}
+
+	if date := d.describeDate(); date != "" {
+		if len(parts) == 0 {
+			date = strings.TrimPrefix(date, "on ")
+		}
+		parts = append(parts, date)
+	}
+
+	// without a time to follow, the timezone ends the description
+	if d.zone != "" && (d.isNow || d.dateOnly) {
+		parts = append(parts, d.zone)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// describeTime describes the time of d, as in "2:00 PM" or "noon".
+func (d *Timespec) describeTime() string {
+	unit := periodWords[d.edgeUnit]
+	if d.fiscal {
+		unit = "fiscal " + unit
+	}
+
+	switch d.edge {
+	case edgeBeginning:
+		return "the beginning of the " + unit
+	case edgeEnd:
+		return "the end of the " + unit
+	}
+
+	return describeClock(d.hours, d.minutes, d.seconds)
+}
+
+// describeDate describes the date of d, as in "tomorrow" or "on
+// February 12th", or returns the empty string if d refers to the date it
+// is resolved against without saying so.
+func (d *Timespec) describeDate() string {
+	switch {
+	case d.isNow:
+		return ""
+	case d.isTomorrow:
+		return "tomorrow"
+	case d.isYesterday:
+		return "yesterday"
+	case d.dayOffset == 2:
+		return "the day after tomorrow"
+	case d.dayOffset == -2:
+		return "the day before yesterday"
+	case d.isTonight:
+		return "tonight"
+	case d.ordinal != 0:
+		ordinal := ordinalNames[d.ordinal]
+		if d.ordinal > 0 {
+			ordinal = fmt.Sprintf("%d%s", d.ordinal, ordinalSuffix(d.ordinal))
+		}
+		return fmt.Sprintf("on the %s %s of %s", ordinal, d.weekday, d.month)
+	case d.isWeekday && d.lastWeekday:
+		return "last " + d.weekday.String()
+	case d.isWeekday && d.nextWeekday:
+		return "next " + d.weekday.String()
+	case d.isWeekday && d.thisWeekday:
+		return "this " + d.weekday.String()
+	case d.isWeekday:
+		return "on " + d.weekday.String()
+	case d.month != 0:
+		return "on " + describeDay(d.month, d.day, d.year)
+	case d.thisPeriod:
+		return "this " + periodWords[d.thisUnit]
+	case d.dateOnly:
+		return "today"
+	}
+
+	return ""
+}
+
+// describeClock describes a time of day on the 12-hour clock, with
+// "noon" and "midnight" for the full hours they stand for.
+func describeClock(hours, minutes, seconds int) string {
+	if minutes == 0 && seconds == 0 {
+		switch hours {
+		case 0:
+			return "midnight"
+		case 12:
+			return "noon"
+		}
+	}
+
+	meridiem := "AM"
+	if hours >= 12 {
+		meridiem = "PM"
+	}
+
+	hours = hours % 12
+	if hours == 0 {
+		hours = 12
+	}
+
+	if seconds != 0 {
+		return fmt.Sprintf("%d:%02d:%02d %s", hours, minutes, seconds, meridiem)
+	}
+
+	return fmt.Sprintf("%d:%02d %s", hours, minutes, meridiem)
+}
+
+// describeDay describes a day of month, as in "February 12th, 2015".  A
+// zero year is left out.
+func describeDay(month time.Month, day, year int) string {
+	if year == 0 {
+		return fmt.Sprintf("%s %d%s", month, day, ordinalSuffix(day))
+	}
+
+	return fmt.Sprintf("%s %d%s, %d", month, day, ordinalSuffix(day), year)
+}
+
+// ordinalSuffix returns the English suffix of the ordinal of n, such as
+// "st" for 1 and "th" for 11.
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+
+	return "th"
+}
+
+// describeIncrements phrases increments relative to base, as in "1 day
+// from now" or "3 days before noon".  Consecutive increments in the same
+// direction are joined, as in "1 month and 15 days from now".
+func describeIncrements(base string, increments []Increment) string {
+	for len(increments) > 0 {
+		n := 1
+		for n < len(increments) && (increments[n].Count < 0) == (increments[0].Count < 0) {
+			n++
+		}
+
+		amounts := []string{}
+		for _, increment := range increments[:n] {
+			amounts = append(amounts, describeAmount(increment))
+		}
+
+		base = fmt.Sprintf("%s %s", strings.Join(amounts, " and "), describeRelation(base, increments[0].Count < 0))
+		increments = increments[n:]
+	}
+
+	return base
+}
+
+// describeAmount describes the size of an increment, as in "2 weeks".
+func describeAmount(increment Increment) string {
+	count := increment.Count
+	if count < 0 {
+		count = -count
+	}
+
+	unit := periodWords[increment.Unit]
+	if count != 1 {
+		unit = plural(unit)
+	}
+
+	return fmt.Sprintf("%d %s", count, unit)
+}
+
+// describeRelation describes the position of an amount of time relative
+// to base, such as "from now" or "before noon".
+func describeRelation(base string, before bool) string {
+	switch {
+	case base == "now" && before:
+		return "ago"
+	case base == "now":
+		return "from now"
+	case before:
+		return "before " + base
+	}
+
+	return "after " + base
+}
@@ -0,0 +1,56 @@
+package timespec
+
+import "testing"
+
+func TestTimespec_Describe(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected string
+	}{
+		{"now", "now"},
+		{"now + 1 day", "1 day from now"},
+		{"now - 2 weeks", "2 weeks ago"},
+		{"now + 1 month + 15 days", "1 month and 15 days from now"},
+		{"now + 1 day - 2 hours", "2 hours before 1 day from now"},
+		{"14:00 Feb 12, 2015", "2:00 PM on February 12th, 2015"},
+		{"noon tomorrow", "noon tomorrow"},
+		{"midnight", "midnight"},
+		{"00:30", "12:30 AM"},
+		{"14:15:30", "2:15:30 PM"},
+		{"9:30 am Friday", "9:30 AM on Friday"},
+		{"10am last Tuesday", "10:00 AM last Tuesday"},
+		{"Feb 12", "February 12th"},
+		{"Mar 23, 2015", "March 23rd, 2015"},
+		{"tomorrow", "tomorrow"},
+		{"yesterday", "yesterday"},
+		{"today", "today"},
+		{"day after tomorrow", "the day after tomorrow"},
+		{"midnight tonight", "midnight tonight"},
+		{"14:00 + 3 days", "3 days after 2:00 PM"},
+		{"noon - 1 hour", "1 hour before noon"},
+		{"14:00 UTC Feb 11", "2:00 PM UTC on February 11th"},
+		{"now UTC", "now UTC"},
+		{"end of month", "the end of the month"},
+		{"2nd Tuesday of March", "the 2nd Tuesday of March"},
+		{"10am last Friday of December", "10:00 AM on the last Friday of December"},
+		{"2015-03-02T14:30:00Z", "2:30 PM on March 2nd, 2015 UTC"},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Describe(); actual != testcase.expected {
+			t.Errorf("Parse(%q).Describe(): expected %q, got %q", testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestOrdinalSuffix(t *testing.T) {
+	for n, expected := range map[int]string{1: "st", 2: "nd", 3: "rd", 4: "th", 11: "th", 12: "th", 13: "th", 21: "st", 22: "nd", 23: "rd", 31: "st", 111: "th"} {
+		if actual := ordinalSuffix(n); actual != expected {
+			t.Errorf("ordinalSuffix(%d): expected %q, got %q", n, expected, actual)
+		}
+	}
+}
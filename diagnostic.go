@@ -0,0 +1,55 @@
+package timespec
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// A Diagnostic describes a suspicious part of a timespec that has been
+// accepted nonetheless, such as a day number beyond the end of the
+// month.
+type Diagnostic struct {
+	// Pos is the offset in bytes the diagnostic refers to
+	Pos int
+	// Msg describes the problem
+	Msg string
+}
+
+// String returns the string representation of a Diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("at position %d: %s", d.Pos, d.Msg)
+}
+
+// ParseWithDiagnostics is like Parse, but additionally returns the
+// diagnostics collected while parsing timespec.
+func ParseWithDiagnostics(timespec string) (*Timespec, []Diagnostic, error) {
+	return defaultParser.ParseWithDiagnostics(timespec)
+}
+
+// ParseWithDiagnostics is like Parse, but additionally returns the
+// diagnostics collected while parsing timespec.
+func (p *Parser) ParseWithDiagnostics(timespec string) (*Timespec, []Diagnostic, error) {
+	spec, err := parse(p, timespec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return spec, spec.diagnostics, nil
+}
+
+// warn records a diagnostic for the current position of in.
+func warn(in io.ByteScanner, spec *Timespec, format string, args ...interface{}) {
+	spec.diagnostics = append(spec.diagnostics, Diagnostic{Pos: offset(in), Msg: fmt.Sprintf(format, args...)})
+}
+
+// daysIn returns the number of days in month.  February has 29 days if
+// year is zero, as the year has yet to be inferred.
+func daysIn(month time.Month, year int) int {
+	if month == time.February && year == 0 {
+		return 29
+	}
+
+	// day 0 of the following month is the last day of this one
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,59 @@
+package timespec
+
+import "testing"
+
+func TestParseWithDiagnostics(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		msg   string
+		pos   int
+	}{
+		{"Feb 31", "February has no day 31, rolling over into the next month", 6},
+		{"noon Feb 30, 2016", "February has no day 30, rolling over into the next month", 17},
+		{"noon Feb 29, 2015", "February has no day 29, rolling over into the next month", 17},
+		{"noon Apr 31", "April has no day 31, rolling over into the next month", 11},
+		{"14:00 CST", `ignored date: date: invalid month name: "CST"`, 9},
+		{"14:00 Feb 12 + 1 eon", `ignored increment: period: invalid period: "eon"`, 20},
+		{"10am UTQ", `ignored timezone: timezone: invalid timezone: "UTQ"`, 8},
+		{"10am until", `ignored date: date: invalid month name: "until"`, 10},
+		{"noon + 1 day foo", `ignored trailing input: "foo"`, 13},
+		// the rest of the word a production failed in is ignored with it
+		{"now zzz", `ignored increment: increment: expected '+' or '-', got 'z'`, 5},
+		{"12 noon", `ignored increment: increment: expected "next", got "no"`, 4},
+	} {
+		spec, diagnostics, err := ParseWithDiagnostics(testcase.input)
+		if err != nil {
+			t.Errorf("ParseWithDiagnostics(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec == nil {
+			t.Errorf("ParseWithDiagnostics(%q): expected a spec", testcase.input)
+		}
+
+		expected := Diagnostic{Pos: testcase.pos, Msg: testcase.msg}
+		if len(diagnostics) != 1 || diagnostics[0] != expected {
+			t.Errorf("ParseWithDiagnostics(%q): expected %s, got %v", testcase.input, expected, diagnostics)
+		}
+	}
+
+	_, diagnostics, _ := NewParser(WordIncrements()).ParseWithDiagnostics("noon pm")
+	expected := Diagnostic{Pos: 6, Msg: `ignored increment: increment: expected "plus", got "pm"`}
+	if len(diagnostics) != 1 || diagnostics[0] != expected {
+		t.Errorf("ParseWithDiagnostics(%q) with WordIncrements: expected %s, got %v", "noon pm", expected, diagnostics)
+	}
+}
+
+func TestParseWithDiagnostics_none(t *testing.T) {
+	for _, input := range []string{
+		"now", "noon", "14:00 Feb 12, 2015", "Feb 29", "noon Feb 29, 2016",
+		"10am tomorrow + 3 days", "now next week", "midnight UTC",
+	} {
+		_, diagnostics, err := ParseWithDiagnostics(input)
+		if err != nil {
+			t.Errorf("ParseWithDiagnostics(%q): %s", input, err)
+		} else if len(diagnostics) != 0 {
+			t.Errorf("ParseWithDiagnostics(%q): expected no diagnostics, got %v", input, diagnostics)
+		}
+	}
+}
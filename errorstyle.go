@@ -0,0 +1,111 @@
+package timespec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An ErrorStyle decides how the messages of ParseErrors read.
+type ErrorStyle int
+
+const (
+	// Detailed keeps the message produced by the parser, such as
+	// `date: invalid month name: "Fbr"`, including low-level details.
+	Detailed ErrorStyle = iota
+	// Terse only names the part of the timespec that is invalid, as in
+	// "invalid date".
+	Terse
+	// Friendly addresses end users, as in "I couldn't understand the
+	// month name 'Fbr'".
+	Friendly
+)
+
+// WithErrorStyle sets how the messages of ParseErrors returned by the
+// parser read.  Positions are reported regardless of the style.  The
+// default is Detailed.
+func WithErrorStyle(style ErrorStyle) Option {
+	return func(p *Parser) {
+		p.errorStyle = style
+	}
+}
+
+// errorSubjects maps the prefixes of parser messages to the parts of a
+// timespec they are about.
+var errorSubjects = map[string]string{
+	"timespec":  "timespec",
+	"rfc3339":   "timestamp",
+	"time":      "time",
+	"clock":     "time",
+	"minute":    "minutes",
+	"second":    "seconds",
+	"am_pm":     "am or pm",
+	"noon":      "time",
+	"midnight":  "time",
+	"edge":      "time",
+	"business":  "time",
+	"date":      "date",
+	"month":     "day of the month",
+	"year":      "year",
+	"increment": "increment",
+	"duration":  "increment",
+	"period":    "unit of the increment",
+	"from":      "increment",
+	"anchor":    "increment",
+	"timezone":  "timezone",
+}
+
+// parseError returns a ParseError for msg at pos in src, phrasing msg in
+// the ErrorStyle p has been configured with.  A nil p uses the default
+// style.
+func (p *Parser) parseError(src string, pos int, msg string) *ParseError {
+	if p == nil {
+		p = defaultParser
+	}
+
+	return &ParseError{Src: src, Pos: pos, Msg: p.errorStyle.phrase(msg, src, pos)}
+}
+
+// phrase rewrites msg, as produced by the parser for an error at pos in
+// src, in style.
+func (style ErrorStyle) phrase(msg, src string, pos int) string {
+	colon := strings.Index(msg, ": ")
+	if colon == -1 || style == Detailed {
+		return msg
+	}
+
+	subject, ok := errorSubjects[msg[:colon]]
+	if !ok {
+		return msg
+	}
+
+	if style == Terse {
+		return "invalid " + subject
+	}
+
+	if strings.HasPrefix(msg[colon+2:], "invalid month name") {
+		subject = "month name"
+	}
+
+	if word := wordBefore(src, pos); word != "" {
+		return fmt.Sprintf("I couldn't understand the %s '%s'", subject, word)
+	}
+
+	return fmt.Sprintf("I couldn't understand the %s", subject)
+}
+
+// wordBefore returns the word of src containing pos, or the word
+// preceding it if pos is at a space.
+func wordBefore(src string, pos int) string {
+	if pos > len(src) {
+		pos = len(src)
+	}
+
+	end := pos
+	for end < len(src) && !isspace(src[end]) {
+		end++
+	}
+
+	head := strings.TrimRight(src[:end], " \t\n")
+
+	return head[strings.LastIndexAny(head, " \t\n")+1:]
+}
@@ -0,0 +1,37 @@
+package timespec
+
+import "testing"
+
+func TestWithErrorStyle(t *testing.T) {
+	const input = "noon Fbr 12"
+
+	for _, testcase := range []struct {
+		style    ErrorStyle
+		expected string
+	}{
+		{Detailed, `date: invalid month name: "Fbr"`},
+		{Terse, "invalid date"},
+		{Friendly, "I couldn't understand the month name 'Fbr'"},
+	} {
+		_, err := NewParser(Strict(), WithErrorStyle(testcase.style)).Parse(input)
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("Parse(%q): expected a *ParseError, got %#v", input, err)
+			continue
+		}
+
+		if perr.Msg != testcase.expected {
+			t.Errorf("Parse(%q): expected message %q, got %q", input, testcase.expected, perr.Msg)
+		}
+	}
+}
+
+func TestWithErrorStyle_keepsPosition(t *testing.T) {
+	_, detailed := Parse("25:00")
+	_, friendly := NewParser(WithErrorStyle(Friendly)).Parse("25:00")
+
+	if detailed.(*ParseError).Pos != friendly.(*ParseError).Pos {
+		t.Errorf("Parse(%q): expected the same position in every style, got %s and %s",
+			"25:00", detailed, friendly)
+	}
+}
@@ -0,0 +1,195 @@
+package timespec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Relativize returns a timespec describing t, preferring a form relative
+// to now.
+//
+// If t lies a whole number of years, months, weeks, days, hours or
+// minutes after now, the result is "now + N unit", using the largest
+// such unit.  If t equals now, the result is "now".  Otherwise the
+// result is the absolute form "HH:MM Mon DD, YYYY" in UTC, which drops
+// any seconds.
+//
+// Parsing the result and resolving it against now yields t again,
+// unless t carried seconds or lies before now.
+func Relativize(t, now time.Time) string {
+	if t.Equal(now) {
+		return "now"
+	}
+
+	if t.After(now) {
+		months := (t.Year()-now.Year())*12 + int(t.Month()-now.Month())
+		if months > 0 && now.AddDate(0, months, 0).Equal(t) {
+			if months%12 == 0 {
+				return formatIncrement("now", months/12, "year")
+			}
+			return formatIncrement("now", months, "month")
+		}
+
+		delta := t.Sub(now)
+		for _, unit := range []struct {
+			duration time.Duration
+			name     string
+		}{
+			{7 * 24 * time.Hour, "week"},
+			{24 * time.Hour, "day"},
+			{time.Hour, "hour"},
+			{time.Minute, "minute"},
+		} {
+			if delta%unit.duration == 0 {
+				return formatIncrement("now", int(delta/unit.duration), unit.name)
+			}
+		}
+	}
+
+	t = t.UTC()
+
+	return fmt.Sprintf("%02d:%02d %s %02d, %04d",
+		t.Hour(), t.Minute(), t.Month().String()[:3], t.Day(), t.Year())
+}
+
+// formatIncrement appends the increment "+ count unit" to base, or "-
+// count unit" for negative counts, pluralizing unit as necessary.
+func formatIncrement(base string, count int, unit string) string {
+	sign := '+'
+	if count < 0 {
+		sign, count = '-', -count
+	}
+
+	if count != 1 {
+		unit = plural(unit)
+	}
+
+	return fmt.Sprintf("%s %c %d %s", base, sign, count, unit)
+}
+
+// String returns the canonical form of d, such as "14:00 Feb 12, 2015 +
+// 3 days" or "now + 1 hour".  Times are written on the 24-hour clock,
+// months and days of the week abbreviated unless d has been parsed
+// with WithNameStyle(Full).  Parsing the result with the options d has
+// been parsed with yields a spec resolving to the same times as d.
+func (d *Timespec) String() string {
+	if !d.instant.IsZero() {
+		return d.instant.Format(time.RFC3339Nano)
+	}
+
+	if d.anchor != "" {
+		return d.formatAnchored()
+	}
+
+	parts := []string{}
+
+	if d.isNow {
+		parts = append(parts, "now")
+	} else if !d.dateOnly {
+		parts = append(parts, d.formatTime())
+		if d.zone != "" {
+			parts = append(parts, d.zone)
+		}
+	}
+
+	if date := d.formatDate(); date != "" {
+		parts = append(parts, date)
+	}
+
+	s := strings.Join(parts, " ")
+	if d.increments != 0 {
+		s = formatIncrement(s, d.increments, periodWords[d.unit])
+	}
+	for _, increment := range d.more {
+		s = formatIncrement(s, increment.Count, periodWords[increment.Unit])
+	}
+
+	// without a time to follow, the timezone ends the timespec
+	if d.zone != "" && (d.isNow || d.dateOnly) {
+		s = s + " " + d.zone
+	}
+
+	return s
+}
+
+// formatTime renders the time of d, which is read the same way
+// regardless of the meridiem options d has been parsed with.
+func (d *Timespec) formatTime() string {
+	unit := periodWords[d.edgeUnit]
+	if d.fiscal {
+		unit = "fiscal " + unit
+	}
+
+	switch d.edge {
+	case edgeBeginning:
+		return "beginning of " + unit
+	case edgeEnd:
+		return "end of " + unit
+	}
+
+	if d.endOfDay {
+		return "midnight"
+	}
+
+	if d.seconds != 0 {
+		return fmt.Sprintf("%02d%02d%02d", d.hours, d.minutes, d.seconds)
+	}
+
+	// four digits are always on the 24-hour clock
+	if d.options().defaultMeridiem != MeridiemNone && d.hours >= 1 && d.hours <= 12 {
+		return fmt.Sprintf("%02d%02d", d.hours, d.minutes)
+	}
+
+	return fmt.Sprintf("%02d:%02d", d.hours, d.minutes)
+}
+
+// formatDate renders the date of d, or returns the empty string if d
+// refers to the date it is resolved against without saying so.
+func (d *Timespec) formatDate() string {
+	switch {
+	case d.isNow:
+		return ""
+	case d.isTomorrow:
+		return "tomorrow"
+	case d.isYesterday:
+		return "yesterday"
+	case d.dayOffset == 2:
+		return "day after tomorrow"
+	case d.dayOffset == -2:
+		return "day before yesterday"
+	case d.isTonight:
+		return "tonight"
+	case d.ordinal != 0:
+		return fmt.Sprintf("%s %s of %s", ordinalNames[d.ordinal],
+			d.formatName(d.weekday.String()), d.formatName(d.month.String()))
+	case d.isWeekday && d.lastWeekday:
+		return "last " + d.formatName(d.weekday.String())
+	case d.isWeekday && d.nextWeekday:
+		return "next " + d.formatName(d.weekday.String())
+	case d.isWeekday && d.thisWeekday:
+		return "this " + d.formatName(d.weekday.String())
+	case d.isWeekday:
+		return d.formatName(d.weekday.String())
+	case d.month != 0 && d.year != 0:
+		return fmt.Sprintf("%s %02d, %04d", d.formatName(d.month.String()), d.day, d.year)
+	case d.month != 0:
+		return fmt.Sprintf("%s %02d", d.formatName(d.month.String()), d.day)
+	case d.thisPeriod:
+		return "this " + periodWords[d.thisUnit]
+	case d.dateOnly:
+		return "today"
+	}
+
+	return ""
+}
+
+// formatName renders the name of a month or a day of the week in the
+// NameStyle d has been parsed with.
+func (d *Timespec) formatName(name string) string {
+	if d.options().nameStyle == Full {
+		return name
+	}
+
+	return name[:3]
+}
@@ -0,0 +1,264 @@
+package timespec
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRelativize(t *testing.T) {
+	now := time.Date(2015, 1, 31, 14, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		t        time.Time
+		expected string
+	}{
+		{now, "now"},
+		{now.Add(3 * 24 * time.Hour), "now + 3 days"},
+		{now.Add(24 * time.Hour), "now + 1 day"},
+		{now.Add(14 * 24 * time.Hour), "now + 2 weeks"},
+		{now.Add(5 * time.Hour), "now + 5 hours"},
+		{now.Add(90 * time.Minute), "now + 90 minutes"},
+		{now.AddDate(0, 2, 0), "now + 2 months"},
+		{now.AddDate(1, 0, 0), "now + 1 year"},
+		{time.Date(2015, 3, 2, 14, 0, 0, 0, time.UTC), "now + 30 days"},
+		{time.Date(2015, 3, 2, 14, 0, 30, 0, time.UTC), "14:00 Mar 02, 2015"},
+		{time.Date(2014, 12, 24, 9, 5, 0, 0, time.UTC), "09:05 Dec 24, 2014"},
+	} {
+		actual := Relativize(testcase.t, now)
+		if actual != testcase.expected {
+			t.Errorf("Relativize(%s, now): expected %q, got %q", testcase.t, testcase.expected, actual)
+			continue
+		}
+
+		spec, err := Parse(actual)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", actual, err)
+			continue
+		}
+
+		expected := testcase.t.Truncate(time.Minute)
+		if resolved := spec.Resolve(now); !resolved.Equal(expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", actual, expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_String(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected string
+	}{
+		{"now", "now"},
+		{"now + 1 day", "now + 1 day"},
+		{"now next week", "now + 1 week"},
+		{"14:00 Feb 12, 2015 + 3 weeks", "14:00 Feb 12, 2015 + 3 weeks"},
+		{"2pm tomorrow", "14:00 tomorrow"},
+		{"noon", "12:00"},
+		{"midnight tonight", "00:00 tonight"},
+		{"9am Friday", "09:00 Fri"},
+		{"10am last Tuesday", "10:00 last Tue"},
+		{"Feb 12", "Feb 12"},
+		{"tomorrow", "tomorrow"},
+		{"yesterday", "yesterday"},
+		{"today", "today"},
+		{"143005", "143005"},
+		{"14:00 -05:00", "14:00 -05:00"},
+		{"end of month", "end of month"},
+		{"beginning of day tomorrow", "beginning of day tomorrow"},
+		{"now PT90M", "now + 90 minutes"},
+		{"2015-03-02T14:30:00+02:00", "2015-03-02T14:30:00+02:00"},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.String(); actual != testcase.expected {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+// generateTimespec returns a random valid timespec combining the
+// features of the grammar.
+func generateTimespec(r *rand.Rand) string {
+	pick := func(choices ...string) string {
+		return choices[r.Intn(len(choices))]
+	}
+
+	months := []string{"Jan", "February", "Mar", "Apr", "May", "June", "Jul", "Aug", "Sep", "October", "Nov", "Dec"}
+	weekdays := []string{"Mon", "Tuesday", "Wed", "Thursday", "Fri", "Sat", "Sunday"}
+
+	date := pick(
+		"",
+		"today",
+		"tomorrow",
+		"tonight",
+		"day after tomorrow",
+		"day before yesterday",
+		weekdays[r.Intn(7)],
+		"last "+weekdays[r.Intn(7)],
+		"on "+weekdays[r.Intn(7)],
+		fmt.Sprintf("%s %02d", months[r.Intn(12)], 1+r.Intn(28)),
+		fmt.Sprintf("%s %02d, %d", months[r.Intn(12)], 1+r.Intn(28), 1990+r.Intn(40)),
+	)
+
+	increment := pick(
+		"",
+		fmt.Sprintf("+ %d %s", 1+r.Intn(20), pick("minutes", "hour", "days", "week", "months", "years", "fortnight", "quarters")),
+		"next "+pick("minute", "hour", "day", "week", "month", "year"),
+		pick("+ P1D", "PT90M", "P2W"),
+	)
+
+	clock := pick(
+		fmt.Sprintf("%d:%02d", r.Intn(24), r.Intn(60)),
+		fmt.Sprintf("%d %s", 1+r.Intn(12), pick("am", "pm", "AM", "PM")),
+		fmt.Sprintf("%d:%02d%s", 1+r.Intn(12), r.Intn(60), pick("am", "pm")),
+		fmt.Sprintf("%02d%02d", r.Intn(24), r.Intn(60)),
+		fmt.Sprintf("%02d%02d%02d", r.Intn(24), r.Intn(60), r.Intn(60)),
+		"noon",
+		"midnight",
+		pick("beginning", "end")+" of "+pick("day", "month", "year"),
+	)
+	if r.Intn(4) == 0 && !strings.Contains(clock, " of ") {
+		clock += " " + pick("UTC", "+0200", "-05:00", "+05:30", "GMT-5")
+	}
+
+	parts := []string{}
+	switch r.Intn(4) {
+	case 0:
+		parts = append(parts, "now", increment)
+	case 1:
+		// "on" only introduces a date following the time
+		date = strings.TrimPrefix(date, "on ")
+		parts = append(parts, date, clock, increment)
+	case 2:
+		if date == "" || strings.HasPrefix(date, "on ") {
+			date = "tomorrow"
+		}
+		parts = append(parts, date, increment)
+	default:
+		parts = append(parts, clock, date, increment)
+	}
+
+	return strings.TrimSpace(strings.Join(strings.Fields(strings.Join(parts, " ")), " "))
+}
+
+func TestTimespec_String_nameStyle(t *testing.T) {
+	full := NewParser(WithNameStyle(Full))
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input       string
+		abbreviated string
+		full        string
+	}{
+		{"14:00 Feb 12, 2015", "14:00 Feb 12, 2015", "14:00 February 12, 2015"},
+		{"noon September 01", "12:00 Sep 01", "12:00 September 01"},
+		{"9am Mon", "09:00 Mon", "09:00 Monday"},
+		{"last Wednesday", "last Wed", "last Wednesday"},
+		{"now + 1 day", "now + 1 day", "now + 1 day"},
+	} {
+		abbreviated, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := abbreviated.String(); actual != testcase.abbreviated {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", testcase.input, testcase.abbreviated, actual)
+		}
+
+		spec, err := full.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q) with Full: %s", testcase.input, err)
+			continue
+		}
+
+		canonical := spec.String()
+		if canonical != testcase.full {
+			t.Errorf("Parse(%q).String() with Full: expected %q, got %q", testcase.input, testcase.full, canonical)
+		}
+
+		reparsed, err := full.Parse(canonical)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", canonical, err)
+		} else if expected, actual := spec.clone().Resolve(now), reparsed.Resolve(now); !actual.Equal(expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", canonical, expected, actual)
+		}
+	}
+}
+
+func TestTimespec_String_parseTimespecTests(t *testing.T) {
+	nows := []time.Time{
+		time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC),
+		time.Date(2016, 12, 31, 23, 30, 0, 0, time.UTC),
+	}
+
+	for _, testcase := range parseTimespecTests {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		canonical := spec.String()
+		reparsed, err := Parse(canonical)
+		if err != nil {
+			t.Errorf("Parse(%q) of Parse(%q).String(): %s", canonical, testcase.input, err)
+			continue
+		}
+
+		if actual := reparsed.String(); actual != canonical {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", canonical, canonical, actual)
+		}
+
+		for _, now := range nows {
+			if expected, actual := spec.Resolve(now), reparsed.Resolve(now); !actual.Equal(expected) {
+				t.Errorf("Parse(%q).String() = %q: resolves to %s against %s, expected %s",
+					testcase.input, canonical, actual, now, expected)
+			}
+		}
+	}
+}
+
+func TestTimespec_String_roundTrip(t *testing.T) {
+	nows := []time.Time{
+		time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC),
+		time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 12, 31, 23, 59, 59, 0, time.UTC),
+	}
+
+	parsers := []*Parser{NewParser(), NewParser(WithDefaultMeridiem(MeridiemPM))}
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		input := generateTimespec(r)
+		parser := parsers[i%len(parsers)]
+
+		spec, err := parser.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		canonical := spec.String()
+		reparsed, err := parser.Parse(canonical)
+		if err != nil {
+			t.Errorf("Parse(%q) of Parse(%q).String(): %s", canonical, input, err)
+			continue
+		}
+
+		for _, now := range nows {
+			expected := spec.clone().Resolve(now)
+			if actual := reparsed.clone().Resolve(now); !actual.Equal(expected) {
+				t.Errorf("Parse(%q).String() = %q: resolves to %s against %s, expected %s",
+					input, canonical, actual, now, expected)
+			}
+		}
+	}
+}
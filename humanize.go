@@ -0,0 +1,93 @@
+package timespec
+
+import (
+	"fmt"
+	"time"
+)
+
+// Humanize returns a coarse description of the point in time described
+// by d relative to now, such as "in 2 hours", "tomorrow" or "3 days
+// ago".
+//
+// The difference between Resolve(now) and now is truncated to whole
+// minutes, hours or days, whichever is the largest unit not exceeding
+// it.  Differences below one minute are described as "now", differences
+// of exactly one day (after truncation) as "tomorrow" or "yesterday".
+func (d *Timespec) Humanize(now time.Time) string {
+	delta := d.Resolve(now).Sub(now)
+
+	past := delta < 0
+	if past {
+		delta = -delta
+	}
+
+	var count int
+	var unit string
+
+	switch {
+	case delta < time.Minute:
+		return "now"
+	case delta < time.Hour:
+		count, unit = int(delta/time.Minute), "minute"
+	case delta < 24*time.Hour:
+		count, unit = int(delta/time.Hour), "hour"
+	default:
+		count, unit = int(delta/(24*time.Hour)), "day"
+	}
+
+	if unit == "day" && count == 1 {
+		if past {
+			return "yesterday"
+		}
+		return "tomorrow"
+	}
+
+	if count != 1 {
+		unit = unit + "s"
+	}
+
+	if past {
+		return fmt.Sprintf("%d %s ago", count, unit)
+	}
+
+	return fmt.Sprintf("in %d %s", count, unit)
+}
+
+// CalendarDiff breaks the difference between Resolve(now) and now down
+// into calendar units, as in "1 month, 3 days".  Units that would be
+// negative borrow from the next larger unit, and days borrow whole
+// months counting back from the month of the later time.  All results
+// are negative or zero if d resolves to a time before now.
+func (d *Timespec) CalendarDiff(now time.Time) (years, months, days, hours, minutes, seconds int) {
+	from, to := now.UTC(), d.Resolve(now).UTC()
+
+	sign := 1
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+
+	years = to.Year() - from.Year()
+	months = int(to.Month() - from.Month())
+	days = to.Day() - from.Day()
+	hours = to.Hour() - from.Hour()
+	minutes = to.Minute() - from.Minute()
+	seconds = to.Second() - from.Second()
+
+	if seconds < 0 {
+		seconds, minutes = seconds+60, minutes-1
+	}
+	if minutes < 0 {
+		minutes, hours = minutes+60, hours-1
+	}
+	if hours < 0 {
+		hours, days = hours+24, days-1
+	}
+	for month := to.Month() - 1; days < 0; month-- {
+		days, months = days+daysIn(month, to.Year()), months-1
+	}
+	if months < 0 {
+		months, years = months+12, years-1
+	}
+
+	return sign * years, sign * months, sign * days, sign * hours, sign * minutes, sign * seconds
+}
@@ -0,0 +1,61 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimespec_Humanize(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		at       Timespec
+		expected string
+	}{
+		{Timespec{isNow: true}, "now"},
+		{Timespec{isNow: true, increments: 1, unit: Minutes}, "in 1 minute"},
+		{Timespec{isNow: true, increments: 45, unit: Minutes}, "in 45 minutes"},
+		{Timespec{isNow: true, increments: 2, unit: Hours}, "in 2 hours"},
+		{Timespec{isNow: true, increments: 1, unit: Days}, "tomorrow"},
+		{Timespec{isNow: true, increments: 3, unit: Days}, "in 3 days"},
+		{Timespec{isNow: true, increments: 2, unit: Weeks}, "in 14 days"},
+		{Timespec{year: 2010, month: 1, day: 1, hours: 14, minutes: 40}, "30 minutes ago"},
+		{Timespec{year: 2010, month: 1, day: 1, hours: 9, minutes: 10}, "6 hours ago"},
+		{Timespec{year: 2009, month: 12, day: 31, hours: 12}, "yesterday"},
+		{Timespec{year: 2009, month: 12, day: 25, hours: 15, minutes: 10}, "7 days ago"},
+	} {
+		if actual := testcase.at.Humanize(now); actual != testcase.expected {
+			t.Errorf("%#v.Humanize(now): expected %q, got %q",
+				testcase.at, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_CalendarDiff(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected [6]int
+	}{
+		{"12:00 Feb 13, 2015", time.Date(2015, 1, 10, 12, 0, 0, 0, time.UTC), [6]int{0, 1, 3, 0, 0, 0}},
+		{"now + 1 month", time.Date(2015, 1, 10, 12, 0, 0, 0, time.UTC), [6]int{0, 1, 0, 0, 0, 0}},
+		{"10:00 Mar 01, 2015", time.Date(2015, 1, 31, 15, 0, 0, 0, time.UTC), [6]int{0, 0, 28, 19, 0, 0}},
+		{"00:00 Jan 01, 2016", time.Date(2015, 12, 31, 23, 59, 30, 0, time.UTC), [6]int{0, 0, 0, 0, 0, 30}},
+		{"08:30 Mar 02, 2017", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC), [6]int{1, 11, 27, 23, 30, 0}},
+		{"12:00 Jan 10, 2015", time.Date(2015, 2, 13, 12, 0, 0, 0, time.UTC), [6]int{0, -1, -3, 0, 0, 0}},
+		{"now", time.Date(2015, 2, 13, 12, 0, 0, 0, time.UTC), [6]int{}},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		var actual [6]int
+		actual[0], actual[1], actual[2], actual[3], actual[4], actual[5] = spec.CalendarDiff(testcase.now)
+		if actual != testcase.expected {
+			t.Errorf("Parse(%q).CalendarDiff(%s): expected %v, got %v",
+				testcase.input, testcase.now, testcase.expected, actual)
+		}
+	}
+}
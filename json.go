@@ -0,0 +1,34 @@
+package timespec
+
+import "encoding/json"
+
+// MarshalJSON encodes d as a JSON string holding its canonical form, as
+// returned by String.
+func (d Timespec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON parses a JSON string holding a timespec into d, using
+// the default options.  The JSON null sets d to the zero Timespec.
+//
+// If the string cannot be parsed, the error is of type *ParseError.
+func (d *Timespec) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = Timespec{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	spec, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*d = *spec
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package timespec
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimespec_JSON_roundTrip(t *testing.T) {
+	type job struct {
+		Name  string
+		At    Timespec
+		Retry *Timespec
+	}
+
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{"now + 1 hour", "noon tomorrow", "14:00 Feb 12, 2015 + 3 days", "last Friday", "2015-03-02T14:30:00Z"} {
+		at, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		retry, _ := Parse("now + 5 minutes")
+
+		data, err := json.Marshal(job{Name: "backup", At: *at, Retry: retry})
+		if err != nil {
+			t.Errorf("json.Marshal(%q): %s", input, err)
+			continue
+		}
+
+		decoded := job{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("json.Unmarshal(%s): %s", data, err)
+			continue
+		}
+
+		if expected, actual := at.Resolve(now), decoded.At.Resolve(now); !actual.Equal(expected) {
+			t.Errorf("json.Unmarshal(%s): At resolves to %s, expected %s", data, actual, expected)
+		}
+
+		if decoded.Retry == nil || decoded.Retry.String() != "now + 5 minutes" {
+			t.Errorf("json.Unmarshal(%s): expected Retry to be %q, got %v", data, "now + 5 minutes", decoded.Retry)
+		}
+	}
+}
+
+func TestTimespec_MarshalJSON(t *testing.T) {
+	spec, err := Parse("noon tomorrow")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon tomorrow", err)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("json.Marshal: %s", err)
+	}
+
+	if string(data) != `"12:00 tomorrow"` {
+		t.Errorf("json.Marshal: expected %s, got %s", `"12:00 tomorrow"`, data)
+	}
+}
+
+func TestTimespec_UnmarshalJSON(t *testing.T) {
+	spec, _ := Parse("noon")
+	if err := json.Unmarshal([]byte("null"), spec); err != nil {
+		t.Errorf("json.Unmarshal(null): %s", err)
+	} else if spec.hours != 0 {
+		t.Errorf("json.Unmarshal(null): expected the zero Timespec, got %#v", spec)
+	}
+
+	err := json.Unmarshal([]byte(`"25:00"`), &Timespec{})
+	if _, ok := err.(*ParseError); !ok {
+		t.Errorf("json.Unmarshal(%q): expected a *ParseError, got %#v", "25:00", err)
+	}
+
+	if err := json.Unmarshal([]byte(`12`), &Timespec{}); err == nil {
+		t.Errorf("json.Unmarshal(12): expected an error")
+	}
+}
@@ -0,0 +1,61 @@
+package timespec
+
+import (
+	"strings"
+	"time"
+)
+
+// ParseLenient parses timespec as far as possible and never fails.
+// Like ParseAbsolute, it resolves the result against now.
+//
+// If timespec cannot be parsed, the word containing the position of
+// the error and everything following it are ignored and the rest is
+// parsed again.  If nothing remains, the result is now.  Every ignored
+// part is recorded in the returned diagnostics, along with those
+// collected while parsing.
+func ParseLenient(timespec string, now time.Time) (*Timespec, []Diagnostic) {
+	return defaultParser.ParseLenient(timespec, now)
+}
+
+// ParseLenient is like the package level ParseLenient, but honors the
+// options p has been configured with.
+func (p *Parser) ParseLenient(timespec string, now time.Time) (*Timespec, []Diagnostic) {
+	ignored := []Diagnostic{}
+	spec := &Timespec{isNow: true, parser: p}
+
+	for src := strings.TrimRight(timespec, " \t\n"); src != ""; {
+		parsed, err := parse(p, src)
+		if err == nil {
+			spec = parsed
+			break
+		}
+
+		perr, ok := err.(*ParseError)
+		if !ok {
+			break
+		}
+		ignored = append([]Diagnostic{{Pos: perr.Pos, Msg: "ignored unparseable input: " + perr.Msg}}, ignored...)
+
+		// back up to the start of the word the error occurred in,
+		// giving up if that does not shorten the input
+		pos := perr.Pos
+		if pos > len(src) {
+			pos = len(src)
+		}
+
+		rest := strings.TrimRight(src[:strings.LastIndexAny(src[:pos], " \t\n")+1], " \t\n")
+		if len(rest) >= len(src) {
+			break
+		}
+		src = rest
+	}
+
+	if strings.TrimSpace(timespec) == "" {
+		ignored = append(ignored, Diagnostic{Pos: 0, Msg: "empty timespec, using now"})
+	}
+
+	absolute := spec.finalized(spec.Resolve(now))
+	absolute.diagnostics = append(spec.diagnostics, ignored...)
+
+	return absolute, absolute.diagnostics
+}
@@ -0,0 +1,72 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLenient(t *testing.T) {
+	now := time.Date(2015, 2, 10, 9, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input       string
+		expected    time.Time
+		diagnostics int
+	}{
+		{"noon tomorrow", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC), 0},
+		{"noon tomorrow !!", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC), 1},
+		{"noon Fbe 12", time.Date(2015, 2, 10, 12, 0, 0, 0, time.UTC), 2},
+		{"noon tomorrow + 999999999999 days", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC), 2},
+		{"13pm tomorrow", now, 1},
+		{"blah", now, 1},
+		{"10:75", now, 1},
+		{"", now, 1},
+		{"   ", now, 1},
+		// trailing whitespace
+		{"Feb ", now, 1},
+		{"noon tomorrow !!  ", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC), 1},
+		{"noon \t", time.Date(2015, 2, 10, 12, 0, 0, 0, time.UTC), 0},
+		// an error at offset 0
+		{"!! noon", now, 1},
+	} {
+		spec, diagnostics := ParseLenient(testcase.input, now)
+		if spec == nil {
+			t.Errorf("ParseLenient(%q): expected a spec", testcase.input)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("ParseLenient(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+
+		if len(diagnostics) != testcase.diagnostics {
+			t.Errorf("ParseLenient(%q): expected %d diagnostics, got %v",
+				testcase.input, testcase.diagnostics, diagnostics)
+		}
+	}
+}
+
+func TestParseLenient_positions(t *testing.T) {
+	now := time.Date(2015, 2, 10, 9, 30, 0, 0, time.UTC)
+
+	_, diagnostics := ParseLenient("noon tomorrow + 999999999999 days", now)
+	if len(diagnostics) == 0 {
+		t.Fatalf("ParseLenient(%q): expected diagnostics", "noon tomorrow + 999999999999 days")
+	}
+
+	if last := diagnostics[len(diagnostics)-1]; last.Pos != 28 {
+		t.Errorf("ParseLenient(%q): expected the error at position 28, got %s",
+			"noon tomorrow + 999999999999 days", last)
+	}
+}
+
+func TestParser_ParseLenient_postResolve(t *testing.T) {
+	now := time.Date(2015, 2, 10, 9, 30, 0, 0, time.UTC)
+	parser := NewParser(WithPostResolve(func(t time.Time) time.Time { return t.Add(time.Hour) }))
+
+	spec, _ := parser.ParseLenient("noon blah", now)
+	if expected, actual := time.Date(2015, 2, 10, 13, 0, 0, 0, time.UTC), spec.Resolve(now); !actual.Equal(expected) {
+		t.Errorf("ParseLenient(%q).Resolve(now): expected %s, got %s", "noon blah", expected, actual)
+	}
+}
@@ -0,0 +1,110 @@
+package timespec
+
+import "regexp"
+
+// trailingMonthDay matches a piece of a list ending in a month name and
+// a day number, which a year may follow after a comma.  leadingListYear
+// matches a piece starting with such a year.
+var (
+	trailingMonthDay = regexp.MustCompile(`([A-Za-z]+)\s+\d{1,2}\s*$`)
+	leadingListYear  = regexp.MustCompile(`^\s*\d{4}(\s|,|$)`)
+)
+
+// ParseList parses a comma-separated list of timespecs, as in "9am,
+// noon, 5pm tomorrow".  A comma between a month and day and a four-digit
+// year, as in "Mar 02, 2015", does not separate entries.
+//
+// The date and increment of the last entry apply to every entry that
+// consists only of a time: "9am, noon, 5pm tomorrow" yields three specs
+// dated tomorrow, whereas in "9am Friday, 5pm tomorrow" each entry
+// keeps its own date.  As "today" is the same as no date at all, it
+// does not keep an entry from inheriting the date of the last one.
+//
+// If an error is returned, it is of type *ParseError and refers to a
+// position in s.
+func ParseList(s string) ([]*Timespec, error) {
+	return defaultParser.ParseList(s)
+}
+
+// ParseList is like the package level ParseList, but honors the options
+// p has been configured with.
+func (p *Parser) ParseList(s string) ([]*Timespec, error) {
+	specs := []*Timespec{}
+
+	for _, entry := range splitList(s) {
+		spec, err := parse(p, s[entry[0]:entry[1]])
+		if err != nil {
+			perr := err.(*ParseError)
+			return nil, &ParseError{Src: s, Pos: entry[0] + perr.Pos, Msg: perr.Msg}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	last := specs[len(specs)-1]
+	for _, spec := range specs[:len(specs)-1] {
+		if spec.isTimeOnly() {
+			spec.inheritDate(last)
+		}
+	}
+
+	return specs, nil
+}
+
+// splitList returns the start and end offsets of the entries of the
+// comma-separated list s.
+func splitList(s string) [][2]int {
+	entries := [][2]int{}
+	start := 0
+
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != ',' {
+			continue
+		}
+
+		if i < len(s) && startsYear(s[start:i], s[i+1:]) {
+			continue
+		}
+
+		for start < i && isspace(s[start]) {
+			start++
+		}
+
+		entries = append(entries, [2]int{start, i})
+		start = i + 1
+	}
+
+	return entries
+}
+
+// startsYear reports whether the comma between before and after
+// separates a month and day from a year.
+func startsYear(before, after string) bool {
+	match := trailingMonthDay.FindStringSubmatch(before)
+	if match == nil || findMonth([]byte(match[1])) == -1 {
+		return false
+	}
+
+	return leadingListYear.MatchString(after)
+}
+
+// inheritDate replaces the date and increment of d by those of other.
+func (d *Timespec) inheritDate(other *Timespec) {
+	d.year, d.month, d.day = other.year, other.month, other.day
+	d.isTomorrow = other.isTomorrow
+	d.isYesterday = other.isYesterday
+	d.dayOffset = other.dayOffset
+	d.isTonight = other.isTonight
+	d.isWeekday = other.isWeekday
+	d.weekday = other.weekday
+	d.lastWeekday = other.lastWeekday
+	d.nextWeekday = other.nextWeekday
+	d.thisWeekday = other.thisWeekday
+	d.thisPeriod = other.thisPeriod
+	d.thisUnit = other.thisUnit
+	d.ordinal = other.ordinal
+	d.increments = other.increments
+	d.unit = other.unit
+	d.hasIncrement = other.hasIncrement
+	d.more = other.more
+}
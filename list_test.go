@@ -0,0 +1,75 @@
+package timespec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected []time.Time
+	}{
+		{"9am, noon, 5pm tomorrow", []time.Time{
+			time.Date(2015, 3, 3, 9, 0, 0, 0, time.UTC),
+			time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC),
+			time.Date(2015, 3, 3, 17, 0, 0, 0, time.UTC),
+		}},
+		{"9am Friday, 5pm tomorrow", []time.Time{
+			time.Date(2015, 3, 6, 9, 0, 0, 0, time.UTC),
+			time.Date(2015, 3, 3, 17, 0, 0, 0, time.UTC),
+		}},
+		{"10:00,14:00 Mar 10, 2016", []time.Time{
+			time.Date(2016, 3, 10, 10, 0, 0, 0, time.UTC),
+			time.Date(2016, 3, 10, 14, 0, 0, 0, time.UTC),
+		}},
+		{"noon Mar 10, 2016, 9am + 1 day", []time.Time{
+			time.Date(2016, 3, 10, 12, 0, 0, 0, time.UTC),
+			time.Date(2015, 3, 3, 9, 0, 0, 0, time.UTC),
+		}},
+		{"now, 8pm", []time.Time{
+			now,
+			time.Date(2015, 3, 2, 20, 0, 0, 0, time.UTC),
+		}},
+	} {
+		specs, err := ParseList(testcase.input)
+		if err != nil {
+			t.Errorf("ParseList(%q): %s", testcase.input, err)
+			continue
+		}
+
+		actual := []time.Time{}
+		for _, spec := range specs {
+			actual = append(actual, spec.Resolve(now))
+		}
+
+		if !reflect.DeepEqual(actual, testcase.expected) {
+			t.Errorf("ParseList(%q):\n  Expected: %v\n       Got: %v", testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParseList_errorPosition(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		pos   int
+	}{
+		{"9am, 25:00", 7},
+		{"9am,, noon", 4},
+	} {
+		_, err := ParseList(testcase.input)
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("ParseList(%q): expected a *ParseError, got %#v", testcase.input, err)
+			continue
+		}
+
+		if perr.Src != testcase.input || perr.Pos != testcase.pos {
+			t.Errorf("ParseList(%q): expected an error at position %d, got %s",
+				testcase.input, testcase.pos, perr)
+		}
+	}
+}
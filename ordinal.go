@@ -0,0 +1,128 @@
+package timespec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ordinalWords maps the ordinals accepted before a day of the week, as
+// in "2nd Tuesday of March", to the occurrence they refer to.  The last
+// occurrence is -1.
+var ordinalWords = map[string]int{
+	"1st": 1, "first": 1,
+	"2nd": 2, "second": 2,
+	"3rd": 3, "third": 3,
+	"4th": 4, "fourth": 4,
+	"5th": 5, "fifth": 5,
+	"last": -1,
+}
+
+// ordinalNames maps occurrences back to the ordinals String writes.
+var ordinalNames = map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", -1: "last"}
+
+// ClampOrdinals makes dates such as "5th Monday of February" refer to
+// the last occurrence of the day of the week in months that lack the
+// requested one.  Without this option, ResolveChecked returns an error
+// for such months and Resolve rolls over into the following month.
+func ClampOrdinals() Option {
+	return func(p *Parser) {
+		p.clampOrdinals = true
+	}
+}
+
+// parseOrdinalWeekday parses a date such as "2nd Tuesday of March" or
+// "last Friday of December" and reports whether it found one.  Any
+// other input, including "last Friday" on its own, is left alone.
+func parseOrdinalWeekday(in io.ByteScanner, spec *Timespec) (bool, error) {
+	if _, ok := in.(*buffer); !ok {
+		return false, nil
+	}
+
+	start := offset(in)
+
+	word := []byte{}
+	any(in, &word, func(c byte) bool { return isalpha(c) || isdigit(c) })
+	ordinal, ok := ordinalWords[strings.ToLower(string(word))]
+
+	weekday := []byte{}
+	skip(in, isspace)
+	any(in, &weekday, isalpha)
+	day := findDayOfWeek(weekday)
+
+	skip(in, isspace)
+	if !ok || day == -1 || !lookingAtWord(in, "of") {
+		rewind(in, start)
+		return false, nil
+	}
+
+	in.ReadByte()
+	in.ReadByte()
+
+	month := []byte{}
+	skip(in, isspace)
+	any(in, &month, isalpha)
+	if findMonth(month) == -1 {
+		return true, fmt.Errorf("date: expected a month after \"of\", got %q", month)
+	}
+
+	spec.ordinal = ordinal
+	spec.weekday = time.Weekday((day + 1) % 7)
+	spec.month = time.Month(findMonth(month))
+
+	return true, nil
+}
+
+// lookingAtOrdinalWeekday reports whether in is positioned at a date
+// such as "2nd Tuesday of March", without consuming any input.
+func lookingAtOrdinalWeekday(in io.ByteScanner) bool {
+	start := offset(in)
+	ok, _ := parseOrdinalWeekday(in, &Timespec{})
+	rewind(in, start)
+
+	return ok
+}
+
+// resolveOrdinalWeekday sets the date of d to the requested occurrence
+// of its day of the week in its month.  The year is that of now, unless
+// that date and time are already before now, in which case it is the
+// following year.
+func (d *Timespec) resolveOrdinalWeekday(now time.Time) error {
+	d.year = now.Year()
+
+	day, err := d.ordinalDay(d.year)
+	if time.Date(d.year, d.month, day, d.hours, d.minutes, d.seconds, 0, now.Location()).Before(now) {
+		d.year++
+		day, err = d.ordinalDay(d.year)
+	}
+
+	d.day = day
+
+	return err
+}
+
+// ordinalDay returns the day of the month the ordinal weekday of d
+// falls on in year.  If the month has no such day, the day returned
+// lies in the following month, or is the last occurrence of the day of
+// the week under ClampOrdinals.
+func (d *Timespec) ordinalDay(year int) (int, error) {
+	days := daysIn(d.month, year)
+
+	if d.ordinal < 0 {
+		last := time.Date(year, d.month, days, 0, 0, 0, 0, time.UTC).Weekday()
+		return days - (int(last)-int(d.weekday)+7)%7, nil
+	}
+
+	first := time.Date(year, d.month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+	day := 1 + (int(d.weekday)-int(first)+7)%7 + 7*(d.ordinal-1)
+	if day <= days {
+		return day, nil
+	}
+
+	if d.options().clampOrdinals {
+		return day - 7, nil
+	}
+
+	return day, fmt.Errorf("resolve: %s %d has no %s %s", d.month, year, ordinalNames[d.ordinal], d.weekday)
+}
@@ -0,0 +1,105 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimespec_Resolve_ordinalWeekday(t *testing.T) {
+	// a Thursday
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2nd Tuesday of March", time.Date(2015, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"last Friday of December", time.Date(2015, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{"first Monday of January", time.Date(2016, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"9am 2nd Tuesday of March", time.Date(2015, 3, 10, 9, 0, 0, 0, time.UTC)},
+		{"third Thursday of Feb 10am", time.Date(2015, 2, 19, 10, 0, 0, 0, time.UTC)},
+		// the 2nd Thursday of February 2015 has passed already
+		{"2nd Thursday of February", time.Date(2016, 2, 11, 0, 0, 0, 0, time.UTC)},
+		{"noon 2nd Thursday of February", time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"last Friday of December + 1 day", time.Date(2015, 12, 26, 0, 0, 0, 0, time.UTC)},
+		{"last Friday", time.Date(2015, 2, 6, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		resolved, err := spec.ResolveChecked(now)
+		if err != nil {
+			t.Errorf("Parse(%q).ResolveChecked(now): %s", testcase.input, err)
+		} else if !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveChecked(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+
+		reparsed, err := Parse(spec.String())
+		if err != nil {
+			t.Errorf("Parse(%q): %s", spec.String(), err)
+		} else if actual := reparsed.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", spec.String(), testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_ResolveInLocation_ordinalWeekday(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// 02:00 on May 01 in UTC, but still Apr 30 in New York
+	now := time.Date(2015, 4, 30, 22, 0, 0, 0, newYork)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"01:00 1st Fri of May", time.Date(2015, 5, 1, 1, 0, 0, 0, newYork)},
+		{"21:00 last Thursday of April", time.Date(2016, 4, 28, 21, 0, 0, 0, newYork)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.ResolveInLocation(now, newYork); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveInLocation(now, New York): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_missingOrdinalWeekday(t *testing.T) {
+	now := time.Date(2015, 2, 1, 8, 0, 0, 0, time.UTC)
+
+	spec, err := Parse("5th Monday of February")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "5th Monday of February", err)
+	}
+
+	if _, err := spec.ResolveChecked(now); err == nil {
+		t.Errorf("Parse(%q).ResolveChecked(now): expected an error", "5th Monday of February")
+	}
+
+	spec, err = NewParser(ClampOrdinals()).Parse("5th Monday of February")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "5th Monday of February", err)
+	}
+
+	expected := time.Date(2015, 2, 23, 0, 0, 0, 0, time.UTC)
+	if resolved, err := spec.ResolveChecked(now); err != nil || !resolved.Equal(expected) {
+		t.Errorf("Parse(%q) with ClampOrdinals: expected %s, got %s (%v)", "5th Monday of February", expected, resolved, err)
+	}
+}
+
+func TestParse_ordinalWeekdayWithoutMonth(t *testing.T) {
+	if _, err := Parse("2nd Tuesday of Foo"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "2nd Tuesday of Foo")
+	}
+}
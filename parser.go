@@ -0,0 +1,487 @@
+package timespec
+
+import "time"
+
+// A Parser parses timespecs according to a set of options.  The zero
+// value and the package level Parse function accept exactly the grammar
+// described in the package documentation; options extend or restrict
+// that grammar.
+type Parser struct {
+	tolerantKeywords     bool
+	singleLetterMeridiem bool
+	rollToFuture         bool
+	wordIncrements       bool
+	leapDayPolicy        LeapDayPolicy
+	twoDigitYears        bool
+	yearPivot            int
+	noIncrements         bool
+	weekend              []time.Weekday
+	requireTime          bool
+	defaultMeridiem      Meridiem
+	timezones            map[string]*time.Location
+	allowedTimezones     []string
+	payload              bool
+	nowSynonyms          []string
+	postResolve          []func(time.Time) time.Time
+	relativeByDefault    bool
+	keywordMinutes       bool
+	businessDaysOnly     bool
+	secondAlignment      SecondAlignment
+	businessHoursSet     bool
+	businessOpen         time.Duration
+	businessClose        time.Duration
+	anchors              map[string]time.Time
+	maxHorizon           time.Duration
+	strict               bool
+	meridiemNames        map[string]Meridiem
+	noiseWords           bool
+	defaultUnitSet       bool
+	defaultUnit          Period
+	afterNext            bool
+	fixedOffset          time.Duration
+	verifyWeekday        bool
+	timesOfDay           map[string]time.Duration
+	nameStyle            NameStyle
+	errorStyle           ErrorStyle
+	midnight             MidnightSemantics
+	fiscalMonth          time.Month
+	fiscalDay            int
+	clampOrdinals        bool
+	dotSeparator         bool
+	collect              bool
+	digitGrouping        bool
+	nextWeekdayMode      NextWeekdayMode
+}
+
+// An Option configures a Parser.
+type Option func(*Parser)
+
+// defaultParser holds the options used when parsing without a Parser.
+var defaultParser = &Parser{}
+
+// strictParser holds the options used by ParseStrict.
+var strictParser = &Parser{strict: true}
+
+// NewParser returns a Parser configured with the given options.
+func NewParser(options ...Option) *Parser {
+	p := &Parser{}
+
+	for _, option := range options {
+		option(p)
+	}
+
+	return p
+}
+
+// Parse parses a timespec, honoring the options p has been configured
+// with.
+//
+// If an error is returned, it is of type *ParseError.
+func (p *Parser) Parse(timespec string) (*Timespec, error) {
+	return parse(p, timespec)
+}
+
+// ParseAbsolute is like the package level ParseAbsolute, but honors the
+// options p has been configured with.
+func (p *Parser) ParseAbsolute(timespec string, now time.Time) (*Timespec, error) {
+	return parseAbsolute(p, timespec, now)
+}
+
+// ParseAndResolve is like the package level ParseAndResolve, but honors
+// the options p has been configured with.
+func (p *Parser) ParseAndResolve(timespec string, now time.Time) (time.Time, error) {
+	return parseAndResolve(p, timespec, now, nil)
+}
+
+// ParseAndResolveIn is like the package level ParseAndResolveIn, but
+// honors the options p has been configured with.
+func (p *Parser) ParseAndResolveIn(timespec string, now time.Time, loc *time.Location) (time.Time, error) {
+	return parseAndResolve(p, timespec, now, loc)
+}
+
+// TolerantKeywords makes the parser accept a small set of common
+// variants of the keyword times: "midnite" for "midnight" and "12 noon"
+// or "12 midnight" for "noon" and "midnight" respectively.
+func TolerantKeywords() Option {
+	return func(p *Parser) {
+		p.tolerantKeywords = true
+	}
+}
+
+// KeywordMinutes makes the parser accept minutes after "noon" and
+// "midnight", as in "noon:30" for 12:30 or "midnight:15" for 00:15.
+func KeywordMinutes() Option {
+	return func(p *Parser) {
+		p.keywordMinutes = true
+	}
+}
+
+// Strict makes the parser return an error for a date or increment it
+// cannot make sense of, instead of ignoring it: "12:00 gibberish" and
+// "now gibberish" are rejected rather than read as "12:00" and "now".
+// So is input left over after a complete timespec, as in "now + 1 day
+// gibberish", and so are days beyond the end of their month, as in
+// "Apr 31" or "Feb 29, 2015", which otherwise roll over into the next
+// month.  February 29 without a year is left to the LeapDayPolicy.
+func Strict() Option {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// DotSeparator makes the parser accept a dot between the hours and
+// minutes of a time, as in "14.30" for 14:30.  Any number following the
+// dot must have two digits, so "14.5" is rejected rather than read as a
+// fraction of an hour.
+func DotSeparator() Option {
+	return func(p *Parser) {
+		p.dotSeparator = true
+	}
+}
+
+// NoiseWords makes the parser accept "sharp" or "exactly" after a time,
+// as in "noon sharp" or "9am exactly".  The word does not change the
+// result.  Without this option, such words are ignored like any other
+// unparseable date, or rejected when parsing with Strict.
+func NoiseWords() Option {
+	return func(p *Parser) {
+		p.noiseWords = true
+	}
+}
+
+// VerifyWeekday makes the parser reject a day of the week preceding a
+// date it does not fall on, as in "Tuesday, March 2, 2015".  Only dates
+// with a year can be checked.  By default, the day of the week is
+// ignored in favor of the date.
+func VerifyWeekday() Option {
+	return func(p *Parser) {
+		p.verifyWeekday = true
+	}
+}
+
+// SingleLetterMeridiem makes the parser accept "A" and "P" on their own
+// as abbreviations for "am" and "pm", as in "10 A" or "10 P".
+func SingleLetterMeridiem() Option {
+	return func(p *Parser) {
+		p.singleLetterMeridiem = true
+	}
+}
+
+// RollToFuture makes Resolve move specs consisting only of a time, such
+// as "9am", to the following day if they would otherwise resolve to a
+// time at or before the reference time.
+func RollToFuture() Option {
+	return func(p *Parser) {
+		p.rollToFuture = true
+	}
+}
+
+// WordIncrements makes the parser accept the word "plus" in place of "+"
+// and spelled out counts from "one" to "twelve" in increments, as in
+// "plus two days" or "next three weeks".
+func WordIncrements() Option {
+	return func(p *Parser) {
+		p.wordIncrements = true
+	}
+}
+
+// DigitGrouping makes the parser accept commas and underscores grouping
+// the digits of increment counts, as in "+ 1,000 minutes" or "+ 10_000
+// seconds".  Separators may only appear between digits.
+func DigitGrouping() Option {
+	return func(p *Parser) {
+		p.digitGrouping = true
+	}
+}
+
+// AfterNext makes the parser accept increments of two periods written
+// as "week after next" or "after next week", optionally preceded by
+// "the", as in "noon the month after next".
+func AfterNext() Option {
+	return func(p *Parser) {
+		p.afterNext = true
+	}
+}
+
+// WithDefaultIncrementUnit makes the parser accept a count without a
+// period after "+" or "plus", as in "now +30", taking the period to be
+// unit.  Without this option, the period is required.
+func WithDefaultIncrementUnit(unit Period) Option {
+	return func(p *Parser) {
+		p.defaultUnitSet = true
+		p.defaultUnit = unit
+	}
+}
+
+// WithNowSynonyms makes the parser accept the given words, ignoring
+// case, wherever it accepts "now", as in "immediately + 1 hour".
+func WithNowSynonyms(synonyms []string) Option {
+	return func(p *Parser) {
+		p.nowSynonyms = synonyms
+	}
+}
+
+// RelativeByDefault makes the parser accept an increment on its own, or
+// preceded by "in", as relative to now: "90 minutes" and "in 90
+// minutes" both mean "now + 90 minutes".
+func RelativeByDefault() Option {
+	return func(p *Parser) {
+		p.relativeByDefault = true
+	}
+}
+
+// WithPostResolve adds a hook adjusting the results of Resolve, for
+// example to skip holidays.  Hooks run in the order they have been
+// added, after the result has been constructed and converted to UTC.
+func WithPostResolve(hook func(time.Time) time.Time) Option {
+	return func(p *Parser) {
+		p.postResolve = append(p.postResolve, hook)
+	}
+}
+
+// WithMaxHorizon makes ResolveChecked return an error if the result is
+// more than horizon after the reference time.  Unlike MaxIncrement, which
+// limits each count, this applies to the resolved time, covering dates
+// far in the future as well as combined increments.  Resolve ignores
+// the horizon.
+func WithMaxHorizon(horizon time.Duration) Option {
+	return func(p *Parser) {
+		p.maxHorizon = horizon
+	}
+}
+
+// WithFixedOffset makes Resolve read specs without a timezone as wall
+// clock values at offset east of UTC, instead of in UTC.  Now is
+// converted to that offset for filling in missing fields.  As the
+// offset never changes, results do not depend on daylight saving time
+// rules or the zoneinfo installed, which keeps test expectations
+// stable.  Results are still returned in UTC.
+func WithFixedOffset(offset time.Duration) Option {
+	return func(p *Parser) {
+		p.fixedOffset = offset
+	}
+}
+
+// A LeapDayPolicy decides how February 29 without a year is resolved if
+// the year inferred for it is not a leap year.
+type LeapDayPolicy int
+
+const (
+	// LenientRollover lets the date roll over to March 1.
+	LenientRollover LeapDayPolicy = iota
+	// NextValidYear moves the date to the next leap year.
+	NextValidYear
+	// ErrorOnInvalid makes ResolveChecked return an error.  Resolve
+	// behaves as with LenientRollover.
+	ErrorOnInvalid
+)
+
+// WithLeapDayPolicy sets the policy for resolving February 29 without a
+// year.  The default is LenientRollover.
+func WithLeapDayPolicy(policy LeapDayPolicy) Option {
+	return func(p *Parser) {
+		p.leapDayPolicy = policy
+	}
+}
+
+// A NextWeekdayMode decides which date "next" followed by a day of the
+// week, as in "next Monday", refers to.
+type NextWeekdayMode int
+
+const (
+	// Coming picks the first date after today falling on the day of
+	// the week, so that "next Friday" on a Tuesday is three days later.
+	Coming NextWeekdayMode = iota
+	// NextWeek picks the day of the week in the following calendar
+	// week, starting on Monday, so that "next Friday" on a Tuesday is
+	// ten days later.
+	NextWeek
+)
+
+// WithNextWeekdayMode sets the mode for resolving "next" followed by a
+// day of the week.  The default is Coming.
+func WithNextWeekdayMode(mode NextWeekdayMode) Option {
+	return func(p *Parser) {
+		p.nextWeekdayMode = mode
+	}
+}
+
+// DefaultYearPivot is a commonly used pivot for WithTwoDigitYears.
+const DefaultYearPivot = 70
+
+// WithTwoDigitYears makes the parser accept two-digit years, as in
+// "Mar 02, 15".  Years below pivot are taken to be in the 2000s, all
+// others in the 1900s.
+func WithTwoDigitYears(pivot int) Option {
+	return func(p *Parser) {
+		p.twoDigitYears = true
+		p.yearPivot = pivot
+	}
+}
+
+// NoIncrements makes the parser reject increments, so that only
+// absolute timespecs such as "noon tomorrow" are accepted.
+func NoIncrements() Option {
+	return func(p *Parser) {
+		p.noIncrements = true
+	}
+}
+
+// RequireTime makes the parser reject timespecs that only consist of a
+// date, such as "Feb 12" or "tomorrow".
+func RequireTime() Option {
+	return func(p *Parser) {
+		p.requireTime = true
+	}
+}
+
+// A Meridiem decides how hours from 1 to 12 without "am" or "pm" are
+// interpreted.
+type Meridiem int
+
+const (
+	// MeridiemNone reads such hours on the 24-hour clock.
+	MeridiemNone Meridiem = iota
+	// MeridiemAM reads such hours as if followed by "am".
+	MeridiemAM
+	// MeridiemPM reads such hours as if followed by "pm".
+	MeridiemPM
+)
+
+// WithDefaultMeridiem sets how hours from 1 to 12 given without "am" or
+// "pm", as in "1" or "9:30", are interpreted.  Four-digit times such as
+// "0130" are unaffected.  The default is MeridiemNone.
+func WithDefaultMeridiem(meridiem Meridiem) Option {
+	return func(p *Parser) {
+		p.defaultMeridiem = meridiem
+	}
+}
+
+// WithMeridiemNames makes the parser accept the given names, ignoring
+// case, in addition to "am" and "pm" after a time, as in "9 nachm." for
+// names mapping "nachm." to MeridiemPM.  Names may contain spaces and
+// punctuation, as in "de l'après-midi".  Names mapped to MeridiemNone
+// are ignored.
+func WithMeridiemNames(names map[string]Meridiem) Option {
+	return func(p *Parser) {
+		p.meridiemNames = names
+	}
+}
+
+// WithTimezoneTable makes the parser accept the timezone abbreviations
+// in table after a time, as in "14:00 CST", ignoring case.  Resolve
+// reads the date and time of such a spec in the location the
+// abbreviation maps to.  Entries in table take precedence over the
+// built-in "UTC".
+func WithTimezoneTable(table map[string]*time.Location) Option {
+	return func(p *Parser) {
+		p.timezones = table
+	}
+}
+
+// WithAllowedTimezones makes the parser reject timezones other than the
+// given ones, ignoring case.  It covers every timezone given by name:
+// "UTC", "GMT", the abbreviations from WithTimezoneTable and IANA names
+// such as "Europe/Berlin".  An offset from "UTC" or "GMT", as in
+// "UTC+2", is allowed along with the name it follows.  Numeric offsets
+// such as "+02:00" and those of RFC 3339 timestamps name no timezone
+// and are always accepted.
+func WithAllowedTimezones(allowed []string) Option {
+	return func(p *Parser) {
+		p.allowedTimezones = allowed
+	}
+}
+
+// A SecondAlignment decides how the seconds of resolved times are
+// aligned to whole minutes.
+type SecondAlignment int
+
+const (
+	// KeepSeconds leaves resolved times as they are.
+	KeepSeconds SecondAlignment = iota
+	// TruncateSeconds sets the seconds of resolved times to zero.
+	TruncateSeconds
+	// RoundSeconds rounds resolved times to the nearest minute, half
+	// a minute rounding up.
+	RoundSeconds
+)
+
+// WithSecondAlignment makes Resolve align its results to whole minutes,
+// as needed for scheduling cron jobs.  Unlike the seconds of a time
+// such as "143005", which are part of the spec, alignment applies to
+// the result after increments have been added, so that "now + 1 hour"
+// is aligned as well.  Hooks given to WithPostResolve see the aligned
+// time.  The default is KeepSeconds.
+func WithSecondAlignment(alignment SecondAlignment) Option {
+	return func(p *Parser) {
+		p.secondAlignment = alignment
+	}
+}
+
+// align applies alignment to t.
+func (alignment SecondAlignment) align(t time.Time) time.Time {
+	switch alignment {
+	case TruncateSeconds:
+		return t.Truncate(time.Minute)
+	case RoundSeconds:
+		return t.Round(time.Minute)
+	}
+
+	return t
+}
+
+// A NameStyle decides how String writes the names of months and days of
+// the week.
+type NameStyle int
+
+const (
+	// Abbreviated writes the first three letters, as in "Feb" and
+	// "Mon", like at(1) does.
+	Abbreviated NameStyle = iota
+	// Full writes the full name, as in "February" and "Monday".
+	Full
+)
+
+// WithNameStyle sets how String writes the names of months and days of
+// the week for specs parsed with the parser.  The default is
+// Abbreviated.
+func WithNameStyle(style NameStyle) Option {
+	return func(p *Parser) {
+		p.nameStyle = style
+	}
+}
+
+// A MidnightSemantics decides which end of a day "midnight" refers to.
+type MidnightSemantics int
+
+const (
+	// StartOfDay reads "midnight" as 00:00 at the start of the day.
+	StartOfDay MidnightSemantics = iota
+	// EndOfDay reads "midnight" as 24:00 at the end of the day, which
+	// is 00:00 on the following day.
+	EndOfDay
+)
+
+// WithMidnightSemantics sets whether "midnight" refers to the start or
+// the end of its day.  Under EndOfDay, "midnight" resolves to the
+// following midnight and "midnight Feb 12" to 00:00 on Feb 13, just as
+// "midnight tonight" does regardless of this option.  Times written as
+// digits, such as "00:00", are not affected.  The default is
+// StartOfDay.
+func WithMidnightSemantics(semantics MidnightSemantics) Option {
+	return func(p *Parser) {
+		p.midnight = semantics
+	}
+}
+
+// WithFiscalYearStart sets the first day of the fiscal year, enabling
+// "beginning of fiscal year" and "end of fiscal year".  These refer to
+// the fiscal year containing the date they are applied to: with a
+// fiscal year starting April 1, "end of fiscal year" on Feb 12, 2015 is
+// 23:59:59 on Mar 31, 2015.  Without this option, fiscal years are
+// rejected.
+func WithFiscalYearStart(month time.Month, day int) Option {
+	return func(p *Parser) {
+		p.fiscalMonth, p.fiscalDay = month, day
+	}
+}
@@ -0,0 +1,1120 @@
+package timespec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParser_TolerantKeywords(t *testing.T) {
+	parser := NewParser(TolerantKeywords())
+
+	for _, testcase := range []struct {
+		input string
+		hours int
+	}{
+		{"midnite", 0},
+		{"midnight", 0},
+		{"12 noon", 12},
+		{"12noon", 12},
+		{"12 midnight", 0},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != 0 {
+			t.Errorf("Parse(%q): expected %02d:00, got %02d:%02d",
+				testcase.input, testcase.hours, spec.hours, spec.minutes)
+		}
+	}
+}
+
+func TestParser_TolerantKeywords_strictByDefault(t *testing.T) {
+	if _, err := Parse("midnite"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "midnite")
+	}
+
+	spec, err := Parse("12 midnight")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "12 midnight", err)
+	}
+
+	if spec.hours != 12 {
+		t.Errorf("Parse(%q): expected \"midnight\" to be ignored, got hours %d",
+			"12 midnight", spec.hours)
+	}
+}
+
+func TestParser_KeywordMinutes(t *testing.T) {
+	parser := NewParser(KeywordMinutes())
+
+	for _, testcase := range []struct {
+		input   string
+		hours   int
+		minutes int
+	}{
+		{"noon:30", 12, 30},
+		{"midnight:45", 0, 45},
+		{"noon", 12, 0},
+		{"noon:30 UTC tomorrow", 12, 30},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes {
+			t.Errorf("Parse(%q): expected %02d:%02d, got %02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, spec.hours, spec.minutes)
+		}
+	}
+
+	for _, input := range []string{"noon:60", "midnight:5", "noon:30:60", "noon:30:"} {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+
+	if spec, err := parser.Parse("noon:30:15"); err != nil {
+		t.Errorf("Parse(%q): %s", "noon:30:15", err)
+	} else if spec.minutes != 30 || spec.seconds != 15 {
+		t.Errorf("Parse(%q): expected 12:30:15, got %02d:%02d:%02d", "noon:30:15", spec.hours, spec.minutes, spec.seconds)
+	}
+
+	if _, err := parser.Parse("noon:60"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "noon:60")
+	} else if perr, ok := err.(*ParseError); !ok || perr.Pos != 5 {
+		t.Errorf("Parse(%q): expected an error at position 5, got %v", "noon:60", err)
+	}
+}
+
+func TestParser_KeywordMinutes_ignoredByDefault(t *testing.T) {
+	spec, err := Parse("noon:30")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon:30", err)
+	}
+
+	if spec.hours != 12 || spec.minutes != 0 {
+		t.Errorf("Parse(%q): expected 12:00, got %02d:%02d",
+			"noon:30", spec.hours, spec.minutes)
+	}
+}
+
+func TestParser_Strict(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected Timespec
+	}{
+		{"now gibberish", Timespec{isNow: true}},
+		{"now +", Timespec{isNow: true}},
+		{"12:00 gibberish", Timespec{hours: 12}},
+		{"12:00 +", Timespec{hours: 12}},
+		{"12:00 Fbe 12", Timespec{hours: 12}},
+	} {
+		spec, diagnostics, err := ParseWithDiagnostics(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+		} else if len(diagnostics) == 0 {
+			t.Errorf("Parse(%q): expected a diagnostic", testcase.input)
+		} else if spec.isNow != testcase.expected.isNow || spec.hours != testcase.expected.hours || spec.increments != 0 || spec.month != 0 {
+			t.Errorf("Parse(%q): expected %s, got %s", testcase.input, &testcase.expected, spec)
+		}
+
+		if _, err := NewParser(Strict()).Parse(testcase.input); err == nil {
+			t.Errorf("Parse(%q) with Strict: expected an error", testcase.input)
+		}
+	}
+
+	for _, input := range []string{"now + 1 day", "12:00 Feb 12 + 1 day", "now UTC"} {
+		if _, err := NewParser(Strict()).Parse(input); err != nil {
+			t.Errorf("Parse(%q) with Strict: %s", input, err)
+		}
+	}
+}
+
+func TestParser_SingleLetterMeridiem(t *testing.T) {
+	parser := NewParser(SingleLetterMeridiem())
+
+	for _, testcase := range []struct {
+		input   string
+		hours   int
+		minutes int
+	}{
+		{"10 A", 10, 0},
+		{"10 P", 22, 0},
+		{"9:30 P", 21, 30},
+		{"10 P UTC", 22, 0},
+		{"10 pm", 22, 0},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes {
+			t.Errorf("Parse(%q): expected %02d:%02d, got %02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes,
+				spec.hours, spec.minutes)
+		}
+	}
+}
+
+func TestParser_SingleLetterMeridiem_rejectedByDefault(t *testing.T) {
+	for _, input := range []string{"10 A", "10 P"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func TestParser_RollToFuture(t *testing.T) {
+	before := time.Date(2010, 1, 1, 8, 0, 0, 0, time.UTC)
+	after := time.Date(2010, 1, 1, 10, 0, 0, 0, time.UTC)
+	today := time.Date(2010, 1, 1, 9, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2010, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		parser   *Parser
+		now      time.Time
+		expected time.Time
+	}{
+		{NewParser(), before, today},
+		{NewParser(), after, today},
+		{NewParser(RollToFuture()), before, today},
+		{NewParser(RollToFuture()), after, tomorrow},
+	} {
+		spec, err := testcase.parser.Parse("9am")
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", "9am", err)
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Resolve(%s) with rollToFuture=%v: expected %s, got %s",
+				testcase.now, testcase.parser.rollToFuture, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_RollToFuture_edges(t *testing.T) {
+	parser := NewParser(RollToFuture())
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"beginning of month", time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of month", time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC), time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC)},
+		{"end of day", time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC), time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s) with RollToFuture: expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_WordIncrements(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+	parser := NewParser(WordIncrements())
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now plus two days", time.Date(2010, 1, 3, 15, 10, 0, 0, time.UTC)},
+		{"now next three weeks", time.Date(2010, 1, 22, 15, 10, 0, 0, time.UTC)},
+		{"now + twelve hours", time.Date(2010, 1, 2, 3, 10, 0, 0, time.UTC)},
+		{"now + 5 minutes", time.Date(2010, 1, 1, 15, 15, 0, 0, time.UTC)},
+		{"now next week", time.Date(2010, 1, 8, 15, 10, 0, 0, time.UTC)},
+		{"10:00 plus one day", time.Date(2010, 1, 2, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_WordIncrements_rejectedByDefault(t *testing.T) {
+	for _, input := range []string{"now plus two days", "now + two days"} {
+		if spec, err := Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		} else if spec.increments != 0 || len(spec.diagnostics) == 0 {
+			t.Errorf("Parse(%q): expected the increment to be ignored, got %d %s (%v)",
+				input, spec.increments, spec.unit, spec.diagnostics)
+		}
+
+		if _, err := NewParser(Strict()).Parse(input); err == nil {
+			t.Errorf("Parse(%q) with Strict: expected an error", input)
+		}
+	}
+}
+
+func TestParser_DigitGrouping(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+	parser := NewParser(DigitGrouping(), Strict())
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now + 1,000 minutes", now.Add(1000 * time.Minute)},
+		{"now + 10_000 seconds", now.Add(10000 * time.Second)},
+		{"now + 1,000,000 seconds", now.Add(1000000 * time.Second)},
+		{"now - 1,440 minutes", now.Add(-1440 * time.Minute)},
+		{"now + 90 minutes", now.Add(90 * time.Minute)},
+		{"10:00 Jan 1, 2010 + 1,000 hours", time.Date(2010, 2, 12, 2, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	for _, input := range []string{"now + 1, minutes", "now + 1__000 seconds"} {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+
+	if _, err := NewParser(Strict()).Parse("now + 1,000 minutes"); err == nil {
+		t.Errorf("Parse(%q) without DigitGrouping: expected an error", "now + 1,000 minutes")
+	}
+}
+
+func TestParser_WithLeapDayPolicy(t *testing.T) {
+	now := time.Date(2010, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		policy   LeapDayPolicy
+		expected time.Time
+		err      bool
+	}{
+		{LenientRollover, time.Date(2010, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{NextValidYear, time.Date(2012, 2, 29, 0, 0, 0, 0, time.UTC), false},
+		{ErrorOnInvalid, time.Time{}, true},
+	} {
+		spec, err := NewParser(WithLeapDayPolicy(testcase.policy)).Parse("midnight Feb 29")
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", "midnight Feb 29", err)
+		}
+
+		resolved, err := spec.ResolveChecked(now)
+		if testcase.err {
+			if err == nil {
+				t.Errorf("policy %d: expected an error, got %s", testcase.policy, resolved)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("policy %d: %s", testcase.policy, err)
+		} else if !resolved.Equal(testcase.expected) {
+			t.Errorf("policy %d: expected %s, got %s", testcase.policy, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_WithLeapDayPolicy_leapYear(t *testing.T) {
+	now := time.Date(2012, 1, 15, 12, 0, 0, 0, time.UTC)
+	expected := time.Date(2012, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	for _, policy := range []LeapDayPolicy{LenientRollover, NextValidYear, ErrorOnInvalid} {
+		spec, _ := NewParser(WithLeapDayPolicy(policy)).Parse("midnight Feb 29")
+
+		if resolved, err := spec.ResolveChecked(now); err != nil || !resolved.Equal(expected) {
+			t.Errorf("policy %d: expected %s, got %s (%v)", policy, expected, resolved, err)
+		}
+	}
+}
+
+func TestParser_WithTwoDigitYears(t *testing.T) {
+	parser := NewParser(WithTwoDigitYears(DefaultYearPivot))
+
+	for _, testcase := range []struct {
+		input string
+		year  int
+	}{
+		{"12:00 Mar 02, 15", 2015},
+		{"12:00 Mar 02, 85", 1985},
+		{"12:00 Mar 02, 69", 2069},
+		{"12:00 Mar 02, 70", 1970},
+		{"12:00 Mar 02, 2015", 2015},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.year != testcase.year {
+			t.Errorf("Parse(%q): expected year %d, got %d", testcase.input, testcase.year, spec.year)
+		}
+	}
+}
+
+func TestParser_NoIncrements(t *testing.T) {
+	parser := NewParser(NoIncrements())
+
+	for _, input := range []string{"noon", "now", "14:00 Feb 12, 2015", "10am tomorrow"} {
+		if _, err := parser.Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		}
+	}
+
+	for _, input := range []string{"now + 1 day", "noon next week", "14:00 Feb 12, 2015 + 3 weeks"} {
+		_, err := parser.Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("Parse(%q): expected a *ParseError, got %#v", input, err)
+		}
+	}
+}
+
+func TestParser_RequireTime(t *testing.T) {
+	parser := NewParser(RequireTime())
+
+	for _, input := range []string{"Feb 12", "tomorrow", "Feb 12 next week"} {
+		if _, err := Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		}
+
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("Parse(%q) with RequireTime: expected an error", input)
+		}
+	}
+
+	for _, input := range []string{"noon Feb 12", "Feb 12 noon", "tomorrow 10am", "now"} {
+		if _, err := parser.Parse(input); err != nil {
+			t.Errorf("Parse(%q) with RequireTime: %s", input, err)
+		}
+	}
+}
+
+func TestParser_WithDefaultMeridiem(t *testing.T) {
+	for _, testcase := range []struct {
+		meridiem Meridiem
+		input    string
+		hours    int
+	}{
+		{MeridiemNone, "1", 1},
+		{MeridiemPM, "1", 13},
+		{MeridiemPM, "1:30", 13},
+		{MeridiemPM, "1 am", 1},
+		{MeridiemPM, "0130", 1},
+		{MeridiemPM, "13", 13},
+		{MeridiemPM, "12", 12},
+		{MeridiemAM, "12", 0},
+		{MeridiemAM, "9", 9},
+		{MeridiemAM, "9 pm", 21},
+	} {
+		spec, err := NewParser(WithDefaultMeridiem(testcase.meridiem)).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours {
+			t.Errorf("Parse(%q) with meridiem %d: expected hours %d, got %d",
+				testcase.input, testcase.meridiem, testcase.hours, spec.hours)
+		}
+	}
+}
+
+func TestParser_WithMeridiemNames(t *testing.T) {
+	parser := NewParser(WithMeridiemNames(map[string]Meridiem{
+		"vorm.":  MeridiemAM,
+		"nachm.": MeridiemPM,
+		"nachts": MeridiemNone,
+	}))
+
+	for _, testcase := range []struct {
+		input string
+		hours int
+	}{
+		{"9 nachm.", 21},
+		{"9:30 NACHM.", 21},
+		{"12 nachm.", 12},
+		{"9 vorm.", 9},
+		{"12 vorm.", 0},
+		{"9 vorm. tomorrow", 9},
+		{"9 pm", 21},
+		{"9 am", 9},
+		{"9", 9},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours {
+			t.Errorf("Parse(%q): expected hours %d, got %d",
+				testcase.input, testcase.hours, spec.hours)
+		}
+	}
+
+	french := NewParser(WithMeridiemNames(map[string]Meridiem{"de l'après-midi": MeridiemPM}), Strict())
+	if spec, err := french.Parse("3 de l'après-midi tomorrow"); err != nil {
+		t.Errorf("Parse(%q): %s", "3 de l'après-midi tomorrow", err)
+	} else if spec.hours != 15 || !spec.isTomorrow {
+		t.Errorf("Parse(%q): expected 15:00 tomorrow, got %s", "3 de l'après-midi tomorrow", spec)
+	}
+
+	if spec, err := parser.Parse("13 nachm."); err == nil {
+		t.Errorf("Parse(%q): expected an error, got %s", "13 nachm.", spec)
+	}
+
+	for _, input := range []string{"9 nachts", "9 nachm.x"} {
+		if spec, err := parser.Parse(input); err == nil && spec.hours != 9 {
+			t.Errorf("Parse(%q): expected hours 9, got %d", input, spec.hours)
+		}
+	}
+
+	if spec, err := Parse("9 nachm."); err == nil && spec.hours != 9 {
+		t.Errorf("Parse(%q) without WithMeridiemNames: expected hours 9, got %d", "9 nachm.", spec.hours)
+	}
+}
+
+func TestParser_NoiseWords(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	parser := NewParser(NoiseWords(), Strict())
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"noon sharp", time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"9am exactly", time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"9am Sharp tomorrow", time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"10:30 exactly + 1 day", time.Date(2015, 2, 13, 10, 30, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"noon sharply", "9am roughly"} {
+		if spec, err := parser.Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error, got %s", input, spec)
+		}
+	}
+
+	if spec, err := NewParser(Strict()).Parse("noon sharp"); err == nil {
+		t.Errorf("Parse(%q) without NoiseWords: expected an error, got %s", "noon sharp", spec)
+	}
+}
+
+func TestParser_WithTimezoneTable(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	now := time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		table    map[string]*time.Location
+		input    string
+		expected time.Time
+	}{
+		{map[string]*time.Location{"CST": chicago}, "14:00 CST Feb 12, 2015", time.Date(2015, 2, 12, 20, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"CST": shanghai}, "14:00 CST Feb 12, 2015", time.Date(2015, 2, 12, 6, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"CST": chicago}, "2pm cst tomorrow", time.Date(2015, 2, 13, 20, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"CST": chicago}, "noon CST", time.Date(2015, 2, 12, 18, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"CST": chicago}, "14:00 UTC Feb 12, 2015", time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"CST": chicago}, "14:00 Feb 12, 2015", time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{map[string]*time.Location{"UTC": chicago}, "14:00 UTC Feb 12, 2015", time.Date(2015, 2, 12, 20, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(WithTimezoneTable(testcase.table)).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_WithAllowedTimezones(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	table := map[string]*time.Location{"CST": chicago, "CET": time.FixedZone("CET", 60*60)}
+	parser := NewParser(WithTimezoneTable(table), WithAllowedTimezones([]string{"cst", "UTC"}))
+
+	for _, input := range []string{"14:00 CST", "14:00 cst Feb 12", "noon UTC", "14:00", "14:00 Feb 12"} {
+		if _, err := parser.Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		}
+	}
+
+	for _, input := range []string{"14:00 CET", "midnight CET tomorrow"} {
+		_, err := parser.Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), "cst, UTC") {
+			t.Errorf("Parse(%q): expected the allowed timezones in %q", input, err)
+		}
+	}
+}
+
+func TestParser_WithAllowedTimezones_names(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	parser := NewParser(WithAllowedTimezones([]string{"Europe/Berlin", "gmt"}))
+
+	for _, input := range []string{"9am Europe/Berlin", "9am europe/berlin tomorrow", "noon GMT", "noon GMT+2", "14:00 +02:00"} {
+		if _, err := parser.Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		}
+	}
+
+	for _, input := range []string{"9am America/New_York", "noon UTC", "noon UTC+2"} {
+		_, err := parser.Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		if !strings.Contains(err.Error(), "Europe/Berlin, gmt") {
+			t.Errorf("Parse(%q): expected the allowed timezones in %q", input, err)
+		}
+	}
+}
+
+func TestParser_WithAllowedTimezones_noUTC(t *testing.T) {
+	parser := NewParser(WithAllowedTimezones([]string{}))
+
+	if _, err := parser.Parse("14:00 UTC"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "14:00 UTC")
+	}
+
+	if _, err := Parse("14:00 UTC"); err != nil {
+		t.Errorf("Parse(%q): %s", "14:00 UTC", err)
+	}
+}
+
+func TestParser_AfterNext(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	parser := NewParser(AfterNext())
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now week after next", time.Date(2015, 2, 26, 8, 0, 0, 0, time.UTC)},
+		{"now month after next", time.Date(2015, 4, 12, 8, 0, 0, 0, time.UTC)},
+		{"noon the week after next", time.Date(2015, 2, 26, 12, 0, 0, 0, time.UTC)},
+		{"now after next week", time.Date(2015, 2, 26, 8, 0, 0, 0, time.UTC)},
+		{"Friday after next week", time.Date(2015, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"now next week", time.Date(2015, 2, 19, 8, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	if spec, err := NewParser(AfterNext(), Strict()).Parse("now after next"); err == nil {
+		t.Errorf("Parse(%q): expected an error, got %s", "now after next", spec)
+	}
+
+	if spec, err := NewParser(Strict()).Parse("now week after next"); err == nil {
+		t.Errorf("Parse(%q) without AfterNext: expected an error, got %s", "now week after next", spec)
+	}
+
+	if _, err := NewParser(AfterNext(), NoIncrements()).Parse("now week after next"); err == nil {
+		t.Errorf("Parse(%q) with NoIncrements: expected an error", "now week after next")
+	}
+}
+
+func TestParser_WithDefaultIncrementUnit(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	parser := NewParser(WithDefaultIncrementUnit(Minutes))
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now +30", time.Date(2015, 2, 12, 8, 30, 0, 0, time.UTC)},
+		{"now + 90", time.Date(2015, 2, 12, 9, 30, 0, 0, time.UTC)},
+		{"noon tomorrow + 15", time.Date(2015, 2, 13, 12, 15, 0, 0, time.UTC)},
+		{"now + 2 hours", time.Date(2015, 2, 12, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	if spec, err := NewParser(WithDefaultIncrementUnit(Days)).Parse("now +3"); err != nil {
+		t.Errorf("Parse(%q): %s", "now +3", err)
+	} else if count, unit, _ := spec.Increment(); count != 3 || unit != Days {
+		t.Errorf("Parse(%q): expected 3 days, got %d %s", "now +3", count, unit)
+	}
+
+	if spec, err := ParseIncrement("+30"); err == nil {
+		t.Errorf("ParseIncrement(%q) without WithDefaultIncrementUnit: expected an error, got %s", "+30", spec)
+	}
+
+	if spec, err := NewParser(Strict()).Parse("now +30"); err == nil {
+		t.Errorf("Parse(%q) without WithDefaultIncrementUnit: expected an error, got %s", "now +30", spec)
+	}
+}
+
+func TestParser_WithNowSynonyms(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+	parser := NewParser(WithNowSynonyms([]string{"immediately", "asap"}))
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"immediately + 1 hour", time.Date(2010, 1, 1, 16, 10, 0, 0, time.UTC)},
+		{"now + 1 hour", time.Date(2010, 1, 1, 16, 10, 0, 0, time.UTC)},
+		{"ASAP", now},
+		{"asap next week", time.Date(2010, 1, 8, 15, 10, 0, 0, time.UTC)},
+		{"noon tomorrow", time.Date(2010, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{"10am", time.Date(2010, 1, 1, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	if _, err := Parse("immediately + 1 hour"); err == nil {
+		t.Errorf("Parse(%q): expected an error without synonyms", "immediately + 1 hour")
+	}
+}
+
+func TestParser_WithPostResolve(t *testing.T) {
+	// 2015-03-01 is a Sunday
+	now := time.Date(2015, 2, 27, 15, 10, 0, 0, time.UTC)
+
+	skipSunday := func(t time.Time) time.Time {
+		if t.Weekday() == time.Sunday {
+			return t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+	addHour := func(t time.Time) time.Time {
+		return t.Add(time.Hour)
+	}
+
+	for _, testcase := range []struct {
+		parser   *Parser
+		input    string
+		expected time.Time
+	}{
+		{NewParser(WithPostResolve(skipSunday)), "noon Mar 01, 2015", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{NewParser(WithPostResolve(skipSunday)), "noon Feb 28, 2015", time.Date(2015, 2, 28, 12, 0, 0, 0, time.UTC)},
+		{NewParser(WithPostResolve(skipSunday)), "now + 2 days", time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)},
+		{NewParser(WithPostResolve(addHour), WithPostResolve(skipSunday)), "23:30 Feb 28, 2015", time.Date(2015, 3, 2, 0, 30, 0, 0, time.UTC)},
+		{NewParser(WithPostResolve(skipSunday), WithPostResolve(addHour)), "23:30 Feb 28, 2015", time.Date(2015, 3, 1, 0, 30, 0, 0, time.UTC)},
+	} {
+		spec, err := testcase.parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_RelativeByDefault(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+	parser := NewParser(RelativeByDefault())
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"in 90 minutes", time.Date(2010, 1, 1, 16, 40, 0, 0, time.UTC)},
+		{"90 minutes", time.Date(2010, 1, 1, 16, 40, 0, 0, time.UTC)},
+		{"now + 90 minutes", time.Date(2010, 1, 1, 16, 40, 0, 0, time.UTC)},
+		{"in 2 weeks", time.Date(2010, 1, 15, 15, 10, 0, 0, time.UTC)},
+		{"1 day", time.Date(2010, 1, 2, 15, 10, 0, 0, time.UTC)},
+		{"in 2025", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"1800", time.Date(2010, 1, 1, 18, 0, 0, 0, time.UTC)},
+		{"10am tomorrow", time.Date(2010, 1, 2, 10, 0, 0, 0, time.UTC)},
+		// further increments follow the leading one
+		{"in 90 minutes + 1 day", time.Date(2010, 1, 2, 16, 40, 0, 0, time.UTC)},
+		{"in 2 hours next week", time.Date(2010, 1, 8, 17, 10, 0, 0, time.UTC)},
+		{"1 day - 30 minutes", time.Date(2010, 1, 2, 14, 40, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	if spec, err := parser.Parse("in 1000000 centuries"); err != nil {
+		t.Errorf("Parse(%q): %s", "in 1000000 centuries", err)
+	} else if count, unit, _ := spec.Increment(); count != 1000000 || unit != Centuries {
+		t.Errorf("Parse(%q): expected 1000000 centuries, got %d %s", "in 1000000 centuries", count, unit)
+	}
+
+	if _, err := parser.Parse("in 1000001 centuries"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "in 1000001 centuries")
+	} else if perr, ok := err.(*ParseError); !ok || perr.Msg != errIncrementTooLarge.Error() {
+		t.Errorf("Parse(%q): expected %q, got %v", "in 1000001 centuries", errIncrementTooLarge, err)
+	}
+
+	if _, err := NewParser(RelativeByDefault(), Strict()).Parse("in 90 minutes + 1 day gibberish"); err == nil {
+		t.Errorf("Parse(%q) with Strict: expected an error", "in 90 minutes + 1 day gibberish")
+	}
+
+	for _, input := range []string{"in 90 minutes", "90 minutes"} {
+		spec, err := Parse(input)
+		if err == nil && spec.isNow {
+			t.Errorf("Parse(%q): expected no relative spec without RelativeByDefault", input)
+		}
+	}
+}
+
+func TestParser_WithSecondAlignment(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 40, 500, time.UTC)
+
+	for _, testcase := range []struct {
+		alignment SecondAlignment
+		input     string
+		expected  time.Time
+	}{
+		{KeepSeconds, "now + 1 hour", time.Date(2015, 3, 2, 10, 30, 40, 0, time.UTC)},
+		{TruncateSeconds, "now + 1 hour", time.Date(2015, 3, 2, 10, 30, 0, 0, time.UTC)},
+		{RoundSeconds, "now + 1 hour", time.Date(2015, 3, 2, 10, 31, 0, 0, time.UTC)},
+		{TruncateSeconds, "143005", time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC)},
+		{RoundSeconds, "143005", time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC)},
+		{RoundSeconds, "143030", time.Date(2015, 3, 2, 14, 31, 0, 0, time.UTC)},
+		{RoundSeconds, "2015-03-02T23:59:45Z", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+	} {
+		parser := NewParser(WithSecondAlignment(testcase.alignment))
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now) with alignment %d: expected %s, got %s",
+				testcase.input, testcase.alignment, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParser_WithFixedOffset(t *testing.T) {
+	parser := NewParser(WithFixedOffset(-5 * time.Hour))
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"9am", time.Date(2015, 1, 15, 12, 0, 0, 0, time.UTC), time.Date(2015, 1, 15, 14, 0, 0, 0, time.UTC)},
+		{"9am", time.Date(2015, 7, 15, 12, 0, 0, 0, time.UTC), time.Date(2015, 7, 15, 14, 0, 0, 0, time.UTC)},
+		// still Mar 07 at the offset
+		{"9am", time.Date(2015, 3, 8, 3, 0, 0, 0, time.UTC), time.Date(2015, 3, 7, 14, 0, 0, 0, time.UTC)},
+		{"midnight tomorrow", time.Date(2015, 3, 7, 12, 0, 0, 0, time.UTC), time.Date(2015, 3, 8, 5, 0, 0, 0, time.UTC)},
+		{"midnight Mar 09, 2015", time.Date(2015, 3, 7, 12, 0, 0, 0, time.UTC), time.Date(2015, 3, 9, 5, 0, 0, 0, time.UTC)},
+		{"9am UTC", time.Date(2015, 7, 15, 12, 0, 0, 0, time.UTC), time.Date(2015, 7, 15, 9, 0, 0, 0, time.UTC)},
+		{"now + 1 day", time.Date(2015, 3, 7, 12, 0, 0, 0, time.UTC), time.Date(2015, 3, 8, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(testcase.now); actual != testcase.expected {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParser_WithMaxHorizon(t *testing.T) {
+	now := time.Date(2016, 3, 2, 9, 30, 0, 0, time.UTC)
+	parser := NewParser(WithMaxHorizon(365 * 24 * time.Hour))
+
+	for _, testcase := range []struct {
+		input string
+		valid bool
+	}{
+		{"now + 365 days", true},
+		{"now + 366 days", false},
+		{"now + 1 year", true},
+		{"now + 8761 hours", false},
+		{"now + 53 weeks", false},
+		{"09:30 Mar 02, 2017", true},
+		{"09:31 Mar 02, 2017", false},
+		{"noon Feb 12, 2010", true},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		_, err = spec.ResolveChecked(now)
+		if testcase.valid && err != nil {
+			t.Errorf("Parse(%q).ResolveChecked(now): %s", testcase.input, err)
+		} else if !testcase.valid && err == nil {
+			t.Errorf("Parse(%q).ResolveChecked(now): expected an error", testcase.input)
+		}
+	}
+}
+
+func TestParser_WithMidnightSemantics(t *testing.T) {
+	now := time.Date(2015, 2, 12, 13, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		semantics MidnightSemantics
+		input     string
+		expected  time.Time
+	}{
+		{StartOfDay, "midnight", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{EndOfDay, "midnight", time.Date(2015, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{StartOfDay, "midnight Feb 20", time.Date(2015, 2, 20, 0, 0, 0, 0, time.UTC)},
+		{EndOfDay, "midnight Feb 20", time.Date(2015, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{EndOfDay, "midnight tonight", time.Date(2015, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{EndOfDay, "00:00", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(WithMidnightSemantics(testcase.semantics)).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		str := spec.String()
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, actual)
+		}
+
+		reparsed, err := NewParser(WithMidnightSemantics(testcase.semantics)).Parse(str)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", str, err)
+		} else if actual := reparsed.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", str, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParser_WithFiscalYearStart(t *testing.T) {
+	parser := NewParser(WithFiscalYearStart(time.April, 1))
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"start of fiscal year", time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC), time.Date(2014, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of fiscal year", time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC)},
+		{"beginning of fiscal year", time.Date(2015, 4, 1, 8, 0, 0, 0, time.UTC), time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of fiscal year", time.Date(2015, 4, 1, 8, 0, 0, 0, time.UTC), time.Date(2016, 3, 31, 23, 59, 59, 0, time.UTC)},
+		{"end of fiscal year Dec 24, 2015", time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC), time.Date(2016, 3, 31, 23, 59, 59, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(testcase.now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", testcase.input, testcase.now, testcase.expected, actual)
+		}
+	}
+
+	if _, err := Parse("end of fiscal year"); err == nil {
+		t.Errorf("Parse(%q): expected an error without a fiscal year start", "end of fiscal year")
+	}
+}
+
+func TestParser_DotSeparator(t *testing.T) {
+	parser := NewParser(DotSeparator())
+
+	for _, testcase := range []struct {
+		input   string
+		hours   int
+		minutes int
+	}{
+		{"14.30", 14, 30},
+		{"9.05 pm", 21, 5},
+		{"14.30 tomorrow", 14, 30},
+		{"14:30", 14, 30},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes {
+			t.Errorf("Parse(%q): expected %02d:%02d, got %02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, spec.hours, spec.minutes)
+		}
+	}
+
+	if _, err := parser.Parse("14.5"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "14.5")
+	}
+
+	spec, err := NewParser(Strict()).Parse("14.30")
+	if err == nil && spec.minutes != 0 {
+		t.Errorf("Parse(%q) without DotSeparator: expected the dot not to separate minutes, got %s", "14.30", spec)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{"12:00 garbage", 13, `date: invalid month name: "garbage"`},
+		{"12:00 until", 11, `date: invalid month name: "until"`},
+		{"12:00 UTQ", 9, `timezone: invalid timezone: "UTQ"`},
+		{"now + 1 day xx", 12, `timespec: unexpected trailing input "xx"`},
+		{"now + 1 day   xx", 14, `timespec: unexpected trailing input "xx"`},
+		{"now + 1 day garbage", 12, `timespec: unexpected trailing input "garbage"`},
+		{"now zzz", 5, `increment: expected '+' or '-', got 'z'`},
+	} {
+		_, err := ParseStrict(testcase.input)
+		if perr, ok := err.(*ParseError); !ok || perr.Pos != testcase.pos || perr.Msg != testcase.msg {
+			t.Errorf("ParseStrict(%q): expected %s at position %d, got %v", testcase.input, testcase.msg, testcase.pos, err)
+		}
+
+		if _, err := Parse(testcase.input); err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+		}
+	}
+
+	for _, input := range []string{"now + 1 day", "12:00 Feb 12 ", "noon\t", "now UTC  "} {
+		if _, err := ParseStrict(input); err != nil {
+			t.Errorf("ParseStrict(%q): %s", input, err)
+		}
+	}
+}
+
+func TestParser_WithNextWeekdayMode(t *testing.T) {
+	// a Saturday
+	now := time.Date(2015, 2, 14, 11, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		mode     NextWeekdayMode
+		expected time.Time
+	}{
+		{"next Monday", Coming, time.Date(2015, 2, 16, 0, 0, 0, 0, time.UTC)},
+		{"next Monday", NextWeek, time.Date(2015, 2, 16, 0, 0, 0, 0, time.UTC)},
+		{"next Sunday", Coming, time.Date(2015, 2, 15, 0, 0, 0, 0, time.UTC)},
+		{"next Sunday", NextWeek, time.Date(2015, 2, 22, 0, 0, 0, 0, time.UTC)},
+		{"next Saturday", Coming, time.Date(2015, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{"next Saturday", NextWeek, time.Date(2015, 2, 21, 0, 0, 0, 0, time.UTC)},
+		{"9am next Monday", NextWeek, time.Date(2015, 2, 16, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(WithNextWeekdayMode(testcase.mode)).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now) in mode %d: expected %s, got %s", testcase.input, testcase.mode, testcase.expected, resolved)
+		}
+	}
+
+	// a Tuesday, with this week's Friday still ahead
+	tuesday := time.Date(2015, 2, 10, 11, 0, 0, 0, time.UTC)
+	for mode, expected := range map[NextWeekdayMode]time.Time{
+		Coming:   time.Date(2015, 2, 13, 0, 0, 0, 0, time.UTC),
+		NextWeek: time.Date(2015, 2, 20, 0, 0, 0, 0, time.UTC),
+	} {
+		spec, err := NewParser(WithNextWeekdayMode(mode)).Parse("next Friday")
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", "next Friday", err)
+		}
+
+		if resolved := spec.Resolve(tuesday); !resolved.Equal(expected) {
+			t.Errorf("Parse(%q).Resolve(%s) in mode %d: expected %s, got %s", "next Friday", tuesday, mode, expected, resolved)
+		}
+	}
+}
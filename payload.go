@@ -0,0 +1,28 @@
+package timespec
+
+import "io"
+
+// AllowPayload makes the parser stop at the first word it cannot make
+// sense of instead of skipping it, as needed for command lines like
+// "10am tomorrow /usr/bin/backup" where a command follows the timespec.
+// The offset of the remaining input is available from Payload.
+func AllowPayload() Option {
+	return func(p *Parser) {
+		p.payload = true
+	}
+}
+
+// Payload returns the byte offset of the input following the timespec
+// in the string d has been parsed from.  It reports false if d has not
+// been parsed with AllowPayload or if nothing follows the timespec.
+func (d *Timespec) Payload() (offset int, ok bool) {
+	return d.payload, d.payload != 0
+}
+
+// backtrack moves in back to pos when parsing with AllowPayload, so that
+// input which could not be parsed is left for the payload.
+func backtrack(in io.ByteScanner, spec *Timespec, pos int) {
+	if spec.options().payload {
+		rewind(in, pos)
+	}
+}
@@ -0,0 +1,57 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParser_AllowPayload(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	parser := NewParser(AllowPayload())
+
+	for _, testcase := range []struct {
+		input    string
+		payload  string
+		expected time.Time
+	}{
+		{"10am tomorrow /usr/bin/backup", "/usr/bin/backup", time.Date(2015, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"tomorrow 10am   /usr/bin/backup --full", "/usr/bin/backup --full", time.Date(2015, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"now + 1 hour echo hello", "echo hello", time.Date(2015, 3, 2, 16, 10, 0, 0, time.UTC)},
+		{"now echo hello", "echo hello", now},
+		{"noon until done", "until done", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"10am backup", "backup", time.Date(2015, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{"Feb 12 run", "run", time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"10am tomorrow", "", time.Date(2015, 3, 3, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		payload := ""
+		if offset, ok := spec.Payload(); ok {
+			payload = testcase.input[offset:]
+		}
+
+		if payload != testcase.payload {
+			t.Errorf("Parse(%q): expected payload %q, got %q", testcase.input, testcase.payload, payload)
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParser_AllowPayload_offByDefault(t *testing.T) {
+	spec, err := Parse("10am tomorrow /usr/bin/backup")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "10am tomorrow /usr/bin/backup", err)
+	}
+
+	if offset, ok := spec.Payload(); ok {
+		t.Errorf("Payload(): expected no payload, got offset %d", offset)
+	}
+}
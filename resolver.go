@@ -0,0 +1,54 @@
+package timespec
+
+import (
+	"sync"
+	"time"
+)
+
+// A Resolver resolves a single spec, remembering the result for the
+// reference time it has been used with most recently.  Resolving a spec
+// over and over against the same time, as when many parts of a program
+// check a schedule against the same tick, only does the work of year
+// inference and weekday resolution once.
+//
+// A Resolver is safe for concurrent use.
+type Resolver struct {
+	spec *Timespec
+
+	mu   sync.Mutex
+	full bool
+	now  time.Time
+	t    time.Time
+	err  error
+}
+
+// Resolver returns a Resolver for d.  Changes to d after calling
+// Resolver do not affect it.
+func (d *Timespec) Resolver() *Resolver {
+	return &Resolver{spec: d.clone()}
+}
+
+// Resolve is the same as the Resolve method of the spec r has been
+// created for.
+func (r *Resolver) Resolve(now time.Time) time.Time {
+	t, _ := r.ResolveChecked(now)
+	return t
+}
+
+// ResolveChecked is the same as the ResolveChecked method of the spec r
+// has been created for.
+func (r *Resolver) ResolveChecked(now time.Time) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the location of now decides the wall clock values used for
+	// filling in missing fields, so it is part of the key
+	if r.full && r.now.Equal(now) && r.now.Location() == now.Location() {
+		return r.t, r.err
+	}
+
+	r.t, r.err = r.spec.resolve(now)
+	r.full, r.now = true, now
+
+	return r.t, r.err
+}
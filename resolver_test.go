@@ -0,0 +1,67 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	later := time.Date(2015, 3, 7, 15, 10, 0, 0, time.UTC)
+	est := time.FixedZone("EST", -5*60*60)
+
+	for _, input := range []string{
+		"now", "now + 1 day", "9am Feb 12", "10am Friday", "noon tomorrow + 2 weeks", "end of month",
+	} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", input, err)
+		}
+		resolver := spec.Resolver()
+
+		for _, reference := range []time.Time{now, now, later, now, now.In(est)} {
+			expected := spec.clone().Resolve(reference)
+
+			if resolved := resolver.Resolve(reference); !resolved.Equal(expected) {
+				t.Errorf("Parse(%q).Resolver().Resolve(%s): expected %s, got %s",
+					input, reference, expected, resolved)
+			}
+		}
+	}
+}
+
+func TestResolver_ResolveChecked(t *testing.T) {
+	now := time.Date(2010, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	spec, err := NewParser(WithLeapDayPolicy(ErrorOnInvalid)).Parse("midnight Feb 29")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "midnight Feb 29", err)
+	}
+	resolver := spec.Resolver()
+
+	for i := 0; i < 2; i++ {
+		if resolved, err := resolver.ResolveChecked(now); err == nil {
+			t.Errorf("ResolveChecked(now): expected an error, got %s", resolved)
+		}
+	}
+}
+
+func BenchmarkTimespec_Resolve(b *testing.B) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	spec, _ := Parse("9am Feb 12 + 3 days")
+
+	for i := 0; i < b.N; i++ {
+		// Resolve fills in the fields of its receiver
+		spec.clone().Resolve(now)
+	}
+}
+
+func BenchmarkResolver_Resolve(b *testing.B) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	spec, _ := Parse("9am Feb 12 + 3 days")
+	resolver := spec.Resolver()
+
+	for i := 0; i < b.N; i++ {
+		resolver.Resolve(now)
+	}
+}
@@ -0,0 +1,104 @@
+package timespec
+
+import "time"
+
+// A Schedule fires at the times a set of timespecs resolve to.  Specs
+// consisting only of a time, such as "9am", fire every day, specs with
+// a day of the week, such as "9am Friday", every week.  All other specs
+// fire once.
+type Schedule struct {
+	specs []*Timespec
+}
+
+// NewSchedule returns a Schedule firing at the times specs resolve to.
+func NewSchedule(specs []*Timespec) *Schedule {
+	return &Schedule{specs: append([]*Timespec(nil), specs...)}
+}
+
+// NextAfter returns the earliest time after t at which s fires,
+// together with the spec firing at that time.  If several specs fire at
+// that time, the first of them is returned.  The boolean result is
+// false if s does not fire after t.
+func (s *Schedule) NextAfter(t time.Time) (time.Time, *Timespec, bool) {
+	var next time.Time
+	var firing *Timespec
+
+	for _, spec := range s.specs {
+		occurrence, ok := spec.occurrence(t, true)
+		if ok && (firing == nil || occurrence.Before(next)) {
+			next, firing = occurrence, spec
+		}
+	}
+
+	return next, firing, firing != nil
+}
+
+// occurrence returns the first time after now that d resolves to if
+// after is set, and the last one at or before now otherwise, treating
+// specs with only a time as daily and specs with a day of the week as
+// weekly.  For a spec occurring once it returns what d resolves to,
+// and false if that lies on the wrong side of now.
+func (d *Timespec) occurrence(now time.Time, after bool) (time.Time, bool) {
+	resolved := d.Resolve(now)
+
+	days := d.recurrence()
+	if days == 0 {
+		return resolved, resolved.After(now) == after
+	}
+
+	if after {
+		for !resolved.After(now) {
+			resolved = resolved.AddDate(0, 0, days)
+		}
+	} else {
+		for resolved.After(now) {
+			resolved = resolved.AddDate(0, 0, -days)
+		}
+	}
+
+	return resolved, true
+}
+
+// Prev returns the most recent time at or before now that d resolves
+// to, as in the last 9am for "9am".  Like in a Schedule, specs with only
+// a time repeat every day and specs with a day of the week every week;
+// all other specs occur once, so that Prev returns the same as Resolve
+// for them, even if that is after now.
+func (d *Timespec) Prev(now time.Time) time.Time {
+	prev, _ := d.occurrence(now, false)
+	return prev
+}
+
+// Next returns the first time after now that d resolves to, as in
+// tomorrow's 9am for "09:00" at 10am.  A spec resolving to now or
+// earlier is moved forward if it repeats: specs with only a time, such
+// as "09:00" or "9am today", by one day, specs with a day of the week,
+// such as "9am Friday", by one week, and specs with a month and day but
+// no year, such as "Feb 12", to the following year.  Any other spec,
+// such as "Feb 12, 2015", "tomorrow" or "noon + 1 day", occurs once and
+// resolves as with Resolve, even if that is not after now.
+func (d *Timespec) Next(now time.Time) time.Time {
+	next, ok := d.occurrence(now, true)
+	if !ok && d.NeedsYearInference() {
+		// resolving at the start of a year picks that year
+		return d.Resolve(time.Date(now.Year()+1, time.January, 1, 0, 0, 0, 0, now.Location()))
+	}
+
+	return next
+}
+
+// recurrence returns the number of days after which d repeats in a
+// Schedule, or 0 if d occurs only once, as specs finalized by
+// ParseAbsolute or ParseLenient do.
+func (d *Timespec) recurrence() int {
+	switch {
+	case d.final:
+		return 0
+	case d.isTimeOnly():
+		return 1
+	case d.isWeekday && !d.lastWeekday && !d.nextWeekday && !d.thisWeekday && !d.isNow && d.increments == 0:
+		return 7
+	}
+
+	return 0
+}
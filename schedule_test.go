@@ -0,0 +1,210 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule_NextAfter(t *testing.T) {
+	specs := []*Timespec{}
+	for _, input := range []string{"9am", "17:30", "noon Feb 12, 2015", "8am Friday"} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", input, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	schedule := NewSchedule(specs)
+
+	for _, testcase := range []struct {
+		after    time.Time
+		expected time.Time
+		spec     *Timespec
+	}{
+		// Wednesday
+		{time.Date(2015, 2, 11, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC), specs[0]},
+		{time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC), time.Date(2015, 2, 11, 17, 30, 0, 0, time.UTC), specs[1]},
+		{time.Date(2015, 2, 11, 18, 0, 0, 0, time.UTC), time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC), specs[0]},
+		{time.Date(2015, 2, 12, 9, 30, 0, 0, time.UTC), time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC), specs[2]},
+		// Thursday evening, Friday 8am comes before 9am
+		{time.Date(2015, 2, 12, 18, 0, 0, 0, time.UTC), time.Date(2015, 2, 13, 8, 0, 0, 0, time.UTC), specs[3]},
+		{time.Date(2015, 2, 13, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC), specs[0]},
+	} {
+		next, spec, ok := schedule.NextAfter(testcase.after)
+		if !ok {
+			t.Errorf("NextAfter(%s): expected %s, got nothing", testcase.after, testcase.expected)
+			continue
+		}
+
+		if !next.Equal(testcase.expected) || spec != testcase.spec {
+			t.Errorf("NextAfter(%s): expected %s from %s, got %s from %s",
+				testcase.after, testcase.expected, testcase.spec, next, spec)
+		}
+	}
+
+	// the specs themselves are left alone
+	if hours := specs[0].hours; hours != 9 || specs[0].year != 0 {
+		t.Errorf("NextAfter modified %#v", specs[0])
+	}
+}
+
+func TestSchedule_NextAfter_exhausted(t *testing.T) {
+	spec, err := Parse("noon Feb 12, 2015")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon Feb 12, 2015", err)
+	}
+
+	after := time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)
+	if next, _, ok := NewSchedule([]*Timespec{spec}).NextAfter(after); ok {
+		t.Errorf("NextAfter(%s): expected nothing, got %s", after, next)
+	}
+
+	instant, err := Parse("2015-02-11T09:00:00Z")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "2015-02-11T09:00:00Z", err)
+	}
+
+	if next, _, ok := NewSchedule([]*Timespec{instant}).NextAfter(after); ok {
+		t.Errorf("NextAfter(%s) of an instant: expected nothing, got %s", after, next)
+	}
+
+	if prev := instant.Prev(after); !prev.Equal(time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Prev(%s) of an instant: expected the instant, got %s", after, prev)
+	}
+
+	if _, _, ok := NewSchedule(nil).NextAfter(after); ok {
+		t.Errorf("NextAfter(%s) of an empty schedule: expected nothing", after)
+	}
+}
+
+func TestSchedule_NextAfter_finalized(t *testing.T) {
+	now := time.Date(2015, 2, 11, 8, 0, 0, 0, time.UTC)
+	nine := time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC)
+
+	absolute, err := ParseAbsolute("9am", now)
+	if err != nil {
+		t.Fatalf("ParseAbsolute(%q): %s", "9am", err)
+	}
+
+	lenient, _ := ParseLenient("9am !!", now)
+
+	for _, spec := range []*Timespec{absolute, lenient} {
+		later := now.Add(48 * time.Hour)
+		if next := spec.Next(later); !next.Equal(nine) {
+			t.Errorf("Next(%s) of %s: expected %s, got %s", later, spec, nine, next)
+		}
+
+		if next, _, ok := NewSchedule([]*Timespec{spec}).NextAfter(later); ok {
+			t.Errorf("NextAfter(%s) of %s: expected nothing, got %s", later, spec, next)
+		}
+
+		earlier := now.Add(-48 * time.Hour)
+		if prev := spec.Prev(earlier); !prev.Equal(nine) {
+			t.Errorf("Prev(%s) of %s: expected %s, got %s", earlier, spec, nine, prev)
+		}
+	}
+}
+
+func TestTimespec_Prev(t *testing.T) {
+	// a Wednesday afternoon
+	now := time.Date(2015, 2, 11, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"midnight", now, time.Date(2015, 2, 11, 0, 0, 0, 0, time.UTC)},
+		{"9am", now, time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC)},
+		{"9am", time.Date(2015, 2, 11, 8, 59, 0, 0, time.UTC), time.Date(2015, 2, 10, 9, 0, 0, 0, time.UTC)},
+		{"9am", time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC), time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC)},
+		{"8am Friday", now, time.Date(2015, 2, 6, 8, 0, 0, 0, time.UTC)},
+		{"Wednesday", now, time.Date(2015, 2, 11, 0, 0, 0, 0, time.UTC)},
+		{"4pm Wednesday", now, time.Date(2015, 2, 4, 16, 0, 0, 0, time.UTC)},
+		{"noon Feb 12, 2015", now, time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Prev(testcase.now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Prev(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_NextPrev_edges(t *testing.T) {
+	now := time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC)
+	endOfMonth := now
+
+	spec, err := Parse("end of month")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "end of month", err)
+	}
+
+	if next := spec.Next(now); !next.Equal(endOfMonth) {
+		t.Errorf("Parse(%q).Next(%s): expected %s, got %s", "end of month", now, endOfMonth, next)
+	}
+
+	if prev := spec.Prev(now); !prev.Equal(endOfMonth) {
+		t.Errorf("Parse(%q).Prev(%s): expected %s, got %s", "end of month", now, endOfMonth, prev)
+	}
+
+	earlier := time.Date(2015, 3, 15, 12, 0, 0, 0, time.UTC)
+	if prev := spec.Prev(earlier); !prev.Equal(endOfMonth) {
+		t.Errorf("Parse(%q).Prev(%s): expected %s, got %s", "end of month", earlier, endOfMonth, prev)
+	}
+}
+
+func TestTimespec_Prev_rollToFuture(t *testing.T) {
+	now := time.Date(2015, 2, 11, 15, 10, 0, 0, time.UTC)
+
+	spec, err := NewParser(RollToFuture()).Parse("9am")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "9am", err)
+	}
+
+	if expected, actual := time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC), spec.Prev(now); !actual.Equal(expected) {
+		t.Errorf("Parse(%q).Prev(now): expected %s, got %s", "9am", expected, actual)
+	}
+}
+
+func TestTimespec_Next(t *testing.T) {
+	// a Thursday
+	early := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	late := time.Date(2015, 2, 12, 10, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"09:00", early, time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"09:00", late, time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"10:00", late, time.Date(2015, 2, 13, 10, 0, 0, 0, time.UTC)},
+		{"9am today", late, time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"9am Thursday", late, time.Date(2015, 2, 19, 9, 0, 0, 0, time.UTC)},
+		{"Thursday", late, time.Date(2015, 2, 19, 0, 0, 0, 0, time.UTC)},
+		{"9am Feb 12", late, time.Date(2016, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"Feb 10", late, time.Date(2016, 2, 10, 0, 0, 0, 0, time.UTC)},
+		{"noon Feb 12", late, time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		// specs occurring once are left alone
+		{"9am Feb 12, 2015", late, time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"now - 1 hour", late, time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"2015-02-11T09:00:00Z", late, time.Date(2015, 2, 11, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if next := spec.Next(testcase.now); !next.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Next(%s): expected %s, got %s", testcase.input, testcase.now, testcase.expected, next)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package timespec
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, storing d as a string holding its
+// canonical form, as returned by String and MarshalJSON.  A nil
+// *Timespec is stored as NULL by database/sql, which Scan turns back
+// into the zero Timespec.
+func (d Timespec) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, parsing a string or []byte holding a
+// timespec into d, using the default options.  NULL sets d to the zero
+// Timespec.
+//
+// If the value cannot be parsed, the error is of type *ParseError.
+func (d *Timespec) Scan(src interface{}) error {
+	var s string
+
+	switch src := src.(type) {
+	case nil:
+		*d = Timespec{}
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("timespec: cannot scan %T into a Timespec", src)
+	}
+
+	spec, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	*d = *spec
+
+	return nil
+}
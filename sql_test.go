@@ -0,0 +1,64 @@
+package timespec
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestTimespec_SQL_roundTrip(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{"00:00", "midnight", "now + 1 day", "14:00 Feb 12, 2015", "noon tomorrow", "last Friday", "2015-03-02T14:30:00Z"} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		value, err := spec.Value()
+		if err != nil {
+			t.Errorf("Parse(%q).Value(): %s", input, err)
+			continue
+		}
+
+		if s, ok := value.(string); !ok || s != spec.String() {
+			t.Errorf("Parse(%q).Value(): expected %q, got %#v", input, spec.String(), value)
+		}
+
+		for _, src := range []interface{}{value, []byte(value.(string))} {
+			scanned := Timespec{}
+			if err := scanned.Scan(src); err != nil {
+				t.Errorf("Scan(%#v): %s", src, err)
+				continue
+			}
+
+			if expected, actual := spec.Resolve(now), scanned.Resolve(now); !actual.Equal(expected) {
+				t.Errorf("Scan(%#v): resolves to %s, expected %s", src, actual, expected)
+			}
+		}
+	}
+}
+
+func TestTimespec_Scan(t *testing.T) {
+	spec, _ := Parse("noon")
+	if err := spec.Scan(nil); err != nil {
+		t.Errorf("Scan(nil): %s", err)
+	} else if spec.hours != 0 {
+		t.Errorf("Scan(nil): expected the zero Timespec, got %#v", spec)
+	}
+
+	var null *Timespec
+	if value, err := driver.DefaultParameterConverter.ConvertValue(null); err != nil || value != nil {
+		t.Errorf("ConvertValue(nil *Timespec): expected NULL, got %#v, %v", value, err)
+	}
+
+	err := (&Timespec{}).Scan("25:00")
+	if _, ok := err.(*ParseError); !ok {
+		t.Errorf("Scan(%q): expected a *ParseError, got %#v", "25:00", err)
+	}
+
+	if err := (&Timespec{}).Scan(12); err == nil {
+		t.Errorf("Scan(12): expected an error")
+	}
+}
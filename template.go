@@ -0,0 +1,119 @@
+package timespec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// templateExamples holds the placeholders understood in templates,
+// along with the values used for checking a template when parsing it.
+var templateExamples = map[string]string{
+	"weekday": "Monday",
+	"month":   "Jan",
+	"day":     "01",
+}
+
+// A Template is a timespec containing placeholders, such as "9am
+// {weekday}", that are filled in each time it is resolved.  The
+// placeholders are "{weekday}", "{month}" and "{day}".
+type Template struct {
+	parser *Parser
+	src    string
+	// names holds the placeholders in the order they appear in src.
+	names []string
+}
+
+// ParseTemplate parses a template.  The template is checked by parsing
+// it with example values for its placeholders, so that errors outside
+// of the placeholders are reported right away.
+//
+// If an error is returned, it is of type *ParseError.
+func ParseTemplate(template string) (*Template, error) {
+	return defaultParser.ParseTemplate(template)
+}
+
+// ParseTemplate is like the package level ParseTemplate, but parses the
+// filled in template according to the options p has been configured
+// with.  Templates are always parsed as with Strict, so that a value
+// that cannot be read as part of the timespec is reported instead of
+// being ignored.
+func (p *Parser) ParseTemplate(template string) (*Template, error) {
+	strict := *p
+	strict.strict = true
+
+	t := &Template{parser: &strict, src: template}
+
+	for pos := 0; pos < len(template); pos++ {
+		if template[pos] != '{' {
+			continue
+		}
+
+		end := strings.IndexByte(template[pos:], '}')
+		if end == -1 {
+			return nil, &ParseError{Src: template, Pos: pos, Msg: "template: unterminated placeholder"}
+		}
+
+		name := template[pos+1 : pos+end]
+		if _, ok := templateExamples[name]; !ok {
+			return nil, &ParseError{Src: template, Pos: pos, Msg: fmt.Sprintf("template: unknown placeholder %q", name)}
+		}
+
+		t.names = append(t.names, name)
+		pos += end
+	}
+
+	if _, err := t.parser.Parse(t.fill(templateExamples)); err != nil {
+		return nil, &ParseError{Src: template, Pos: 0, Msg: err.(*ParseError).Msg}
+	}
+
+	return t, nil
+}
+
+// Expand returns the timespec t stands for with its placeholders
+// replaced by the values in vars, keyed by placeholder name without
+// braces.  It returns an error if vars lacks a placeholder of t.
+func (t *Template) Expand(vars map[string]string) (string, error) {
+	for _, name := range t.names {
+		if _, ok := vars[name]; !ok {
+			return "", fmt.Errorf("template: missing value for {%s}", name)
+		}
+	}
+
+	return t.fill(vars), nil
+}
+
+// Resolve fills in the placeholders of t with the values in vars, as
+// Expand does, and resolves the resulting timespec against now.
+//
+// If the filled in template cannot be parsed, the error is of type
+// *ParseError.
+func (t *Template) Resolve(now time.Time, vars map[string]string) (time.Time, error) {
+	timespec, err := t.Expand(vars)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	spec, err := t.parser.Parse(timespec)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return spec.Resolve(now), nil
+}
+
+// String returns the template as it has been parsed.
+func (t *Template) String() string {
+	return t.src
+}
+
+// fill replaces every placeholder in the source of t by its value in
+// vars.
+func (t *Template) fill(vars map[string]string) string {
+	replacements := []string{}
+	for name := range templateExamples {
+		replacements = append(replacements, "{"+name+"}", vars[name])
+	}
+
+	return strings.NewReplacer(replacements...).Replace(t.src)
+}
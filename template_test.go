@@ -0,0 +1,81 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplate_Resolve(t *testing.T) {
+	// a Thursday
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		template string
+		vars     map[string]string
+		expected time.Time
+	}{
+		{"9am {weekday}", map[string]string{"weekday": "Friday"}, time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"9am {weekday}", map[string]string{"weekday": "Mon"}, time.Date(2015, 2, 16, 9, 0, 0, 0, time.UTC)},
+		{"noon {month} {day}, 2015", map[string]string{"month": "Mar", "day": "02"}, time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"{weekday} 10:30 + 1 week", map[string]string{"weekday": "Tuesday"}, time.Date(2015, 2, 24, 10, 30, 0, 0, time.UTC)},
+		{"now + 1 hour", nil, time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+	} {
+		template, err := ParseTemplate(testcase.template)
+		if err != nil {
+			t.Errorf("ParseTemplate(%q): %s", testcase.template, err)
+			continue
+		}
+
+		actual, err := template.Resolve(now, testcase.vars)
+		if err != nil {
+			t.Errorf("ParseTemplate(%q).Resolve(now, %v): %s", testcase.template, testcase.vars, err)
+			continue
+		}
+
+		if !actual.Equal(testcase.expected) {
+			t.Errorf("ParseTemplate(%q).Resolve(now, %v): expected %s, got %s",
+				testcase.template, testcase.vars, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTemplate_Resolve_invalidValues(t *testing.T) {
+	template, err := ParseTemplate("9am {weekday}")
+	if err != nil {
+		t.Fatalf("ParseTemplate(%q): %s", "9am {weekday}", err)
+	}
+
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	if _, err := template.Resolve(now, map[string]string{}); err == nil {
+		t.Errorf("Resolve(now, {}): expected an error for the missing weekday")
+	}
+
+	if _, err := template.Resolve(now, map[string]string{"weekday": "Funday"}); err == nil {
+		t.Errorf("Resolve(now, {weekday: Funday}): expected an error")
+	} else if _, ok := err.(*ParseError); !ok {
+		t.Errorf("Resolve(now, {weekday: Funday}): expected a *ParseError, got %#v", err)
+	}
+}
+
+func TestParseTemplate_errors(t *testing.T) {
+	for _, testcase := range []struct {
+		template string
+		pos      int
+	}{
+		{"9am {weekday", 4},
+		{"9am {hour}", 4},
+		{"25:00 {weekday}", 0},
+	} {
+		_, err := ParseTemplate(testcase.template)
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("ParseTemplate(%q): expected a *ParseError, got %#v", testcase.template, err)
+			continue
+		}
+
+		if perr.Pos != testcase.pos {
+			t.Errorf("ParseTemplate(%q): expected an error at %d, got %s", testcase.template, testcase.pos, perr)
+		}
+	}
+}
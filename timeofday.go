@@ -0,0 +1,58 @@
+package timespec
+
+import (
+	"io"
+	"time"
+)
+
+// defaultTimesOfDay holds the times of day, as offsets from midnight,
+// the phrases following a date refer to unless configured otherwise
+// with WithTimesOfDay.
+var defaultTimesOfDay = map[string]time.Duration{
+	"morning":   9 * time.Hour,
+	"afternoon": 15 * time.Hour,
+	"evening":   18 * time.Hour,
+}
+
+// WithTimesOfDay sets the times of day, as offsets from midnight, that
+// phrases such as "morning" refer to when following a date, as in
+// "tomorrow morning" or "Friday evening".  Entries in times replace the
+// defaults of the same name, which are 9:00 for "morning", 15:00 for
+// "afternoon" and 18:00 for "evening", and add new phrases otherwise.
+func WithTimesOfDay(times map[string]time.Duration) Option {
+	return func(p *Parser) {
+		p.timesOfDay = times
+	}
+}
+
+// findTimeOfDay returns the phrase set with WithTimesOfDay or one of
+// the defaults that in is positioned at, ignoring case, along with its
+// time of day.  The phrase is "" if there is none.
+func findTimeOfDay(in io.ByteScanner, spec *Timespec) (string, time.Duration) {
+	for _, times := range []map[string]time.Duration{spec.options().timesOfDay, defaultTimesOfDay} {
+		for phrase, at := range times {
+			if lookingAtWord(in, phrase) {
+				return phrase, at
+			}
+		}
+	}
+
+	return "", 0
+}
+
+// parseTimeOfDay consumes phrase, as found by findTimeOfDay, and sets
+// the time of spec to at.
+func parseTimeOfDay(in io.ByteScanner, spec *Timespec, phrase string, at time.Duration) {
+	for i := 0; i < len(phrase); i++ {
+		in.ReadByte()
+	}
+
+	setClock(spec, at)
+}
+
+// setClock sets the time of spec to at, an offset from midnight.
+func setClock(spec *Timespec, at time.Duration) {
+	spec.hours = int(at / time.Hour)
+	spec.minutes = int(at % time.Hour / time.Minute)
+	spec.seconds = int(at % time.Minute / time.Second)
+}
@@ -0,0 +1,52 @@
+package timespec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_timeOfDay(t *testing.T) {
+	// a Thursday
+	now := time.Date(2015, 2, 12, 20, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		options  []Option
+		input    string
+		expected time.Time
+	}{
+		{nil, "tomorrow morning", time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{nil, "tomorrow afternoon", time.Date(2015, 2, 13, 15, 0, 0, 0, time.UTC)},
+		{nil, "tomorrow evening", time.Date(2015, 2, 13, 18, 0, 0, 0, time.UTC)},
+		{nil, "tomorrow Evening + 1 hour", time.Date(2015, 2, 13, 19, 0, 0, 0, time.UTC)},
+		{nil, "Monday morning", time.Date(2015, 2, 16, 9, 0, 0, 0, time.UTC)},
+		{nil, "Mar 02, 2015 evening", time.Date(2015, 3, 2, 18, 0, 0, 0, time.UTC)},
+		{nil, "tomorrow midnight", time.Date(2015, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{nil, "tomorrow end of day", time.Date(2015, 2, 13, 23, 59, 59, 0, time.UTC)},
+		{
+			[]Option{WithTimesOfDay(map[string]time.Duration{"morning": 7*time.Hour + 30*time.Minute})},
+			"tomorrow morning",
+			time.Date(2015, 2, 13, 7, 30, 0, 0, time.UTC),
+		},
+		{
+			[]Option{WithTimesOfDay(map[string]time.Duration{"morning": 7 * time.Hour})},
+			"tomorrow evening",
+			time.Date(2015, 2, 13, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			[]Option{WithTimesOfDay(map[string]time.Duration{"lunchtime": 12*time.Hour + 30*time.Minute})},
+			"tomorrow lunchtime",
+			time.Date(2015, 2, 13, 12, 30, 0, 0, time.UTC),
+		},
+	} {
+		spec, err := NewParser(testcase.options...).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
@@ -7,19 +7,36 @@
 // time.  Times can be specified in hours (24-hour clock or wall clock),
 // optionally followed by minutes. Additionally "noon" is recognized as
 // an abbreviation for "12 pm" and "midnight" is an abbreviation for "12
-// am".  The following are all valid times: "now", "1 am", "14:15", "1800".
+// am".  "am" and "pm" may also be written "a.m." and "p.m.".  The
+// following are all valid times: "now", "1 am", "2 p.m.", "14:15", "1800".
 //
 // A date can either be a day of the week, such as "Tue" or "Tuesday",
-// or a month name followed by a day number and optionally a year.  The
-// strings "today" and "tomorrow" are also recognized as dates,
-// indicating the obvious.  The following are all valid dates: "Feb 01",
-// "today", "Mar 02, 2015", "tomorrow".
+// optionally preceded by "last", "next" or "this", or a month name
+// followed by a day number and optionally a year.  The strings "today",
+// "tomorrow" and "yesterday" are also recognized as dates, indicating
+// the obvious, and so are "in 2025" and "year 2025" for January 1 of a
+// year.  "tonight" is the same as "today", except that "midnight
+// tonight" refers to the midnight at the end of today.  A day of the
+// week may precede a month and day, as in "Monday, March 2, 2015", or
+// be preceded by an ordinal and followed by a month, as in "2nd Tuesday
+// of March" or "last Friday of December".  ISO 8601 calendar dates such
+// as "2015-03-02" are understood as well.  The comma before a year is
+// optional, so that four digits following a month and day are always a
+// year: "Mar 2 1800" is in the year 1800, while 6pm on March 2 is "Mar
+// 2 18:00".  The following are all valid dates: "Feb 01", "today", "Mar
+// 2, 2015", "Mar 2 2015", "tomorrow".  A date given first may be
+// followed by "morning", "afternoon" or "evening" in place of a time,
+// as in "tomorrow morning".
 //
 // Increments are useful for describing points in time relative to a
 // reference time such as "now".  An increment is either "+" or the word
 // "next", followed by a number and a unit such as "month".  The
 // following are all valid increments: "+ 1 year", "next week", "+ 10
-// minutes".
+// minutes".  An increment may also start a timespec if followed by "from
+// now", "from today" or "from tomorrow", as in "+ 3 days from tomorrow".
+// A "-" in place of the "+" subtracts the increment, as in "now - 1
+// hour".  Several increments may follow each other, as in "now + 1 day
+// + 2 hours"; they are applied in the order given.
 //
 // The syntax of timespec implemented by this package is the one
 // understood by at(1) and reproduced here for convenience:
@@ -139,9 +156,20 @@
 //
 //    date        : month_name day_number
 //                | month_name day_number "," year_number
+//                | month_name day_number year_number
 //                | day_of_week
+//                | "last" day_of_week
+//                | "next" day_of_week
+//                | "this" day_of_week
+//                | "this" inc_period
+//                | "in" year_number
+//                | "year" year_number
 //                | "today"
+//                | "tonight"
 //                | "tomorrow"
+//                | "yesterday"
+//                | "day" "after" "tomorrow"
+//                | "day" "before" "yesterday"
 //                ;
 //
 //
@@ -158,8 +186,12 @@
 //                | "year" | "years"
 //                ;
 //
-// The only valid timezone_name recognized by this implementation is
-// "UTC" (matched case-insensitively).
+// The timezone_names recognized by this implementation are "UTC" and
+// "GMT" (matched case-insensitively), optionally followed by an offset,
+// as in "UTC+2" or "GMT-05:30", and IANA names such as
+// "America/New_York", which are loaded with time.LoadLocation.  Besides
+// following the time, a timezone may end any timespec, as in "noon Feb
+// 12 UTC", "Tuesday UTC" or "now + 1 day UTC".
 package timespec
 
 import (
@@ -184,8 +216,92 @@ type Timespec struct {
 	seconds    int
 	isNow      bool
 	isTomorrow bool
-	increments int
-	unit       incrementType
+	// isYesterday is set for "yesterday", which moves the date one day
+	// back.
+	isYesterday bool
+	// dayOffset is the number of days "day after tomorrow" and "day
+	// before yesterday" move the date relative to today.
+	dayOffset int
+	// isTonight is set for "tonight", which moves midnight to the end
+	// of the day.
+	isTonight bool
+	// endOfDay is set for "midnight" parsed with EndOfDay, which moves
+	// it to the end of the day like "tonight" does.
+	endOfDay bool
+	// ordinal is set for dates such as "2nd Tuesday of March", which
+	// refer to an occurrence of weekday within month.  The last
+	// occurrence is -1.
+	ordinal int
+	// isWeekday is set if the date is given as a day of the week,
+	// which is stored in weekday.  lastWeekday is set if it has been
+	// preceded by "last".
+	isWeekday   bool
+	weekday     time.Weekday
+	lastWeekday bool
+	// nextWeekday and thisWeekday are set if the day of the week has
+	// been preceded by "next" or "this".
+	nextWeekday bool
+	thisWeekday bool
+	increments  int
+	unit        Period
+	// hasIncrement is set if an increment has been given, which
+	// distinguishes "+ 0 days" from no increment at all.
+	hasIncrement bool
+	// more holds the increments following the first one, as in "+ 1
+	// day + 2 hours".
+	more []Increment
+
+	// instant is set for RFC 3339 timestamps, which denote a point in
+	// time directly.
+	instant time.Time
+
+	// final is set if instant has been resolved already and passed
+	// through the alignment and hooks of the parser, as by
+	// ParseAbsolute, so that resolving d leaves it alone.
+	final bool
+
+	// thisPeriod is set for "this week" and the like, which refer to the
+	// current period of thisUnit and thus to today.
+	thisPeriod bool
+	thisUnit   Period
+
+	// dateOnly is set if no time has been given, so that the time
+	// defaults to midnight.
+	dateOnly bool
+
+	// edge and edgeUnit record phrases like "end of month", which
+	// replace the time by the first or last instant of a period.
+	// fiscal is set if the period is a fiscal year.
+	edge     edgeType
+	edgeUnit Period
+	fiscal   bool
+
+	// location is the zone a timezone abbreviation or offset denotes,
+	// nil means UTC.  zone is the timezone as given.  namedZone is set
+	// for IANA timezone names, whose location is kept by Resolve.
+	location  *time.Location
+	zone      string
+	namedZone bool
+
+	// anchor is the name of the point in time given to WithAnchors
+	// that takes the place of now.
+	anchor string
+
+	// payload is the offset of the input following the timespec when
+	// parsing with AllowPayload, or 0.
+	payload int
+
+	// diagnostics collects the problems the parser has recovered
+	// from, see ParseWithDiagnostics.
+	diagnostics []Diagnostic
+
+	// errors collects the errors the parser has recovered from, see
+	// ParseCollect.
+	errors []Diagnostic
+
+	// parser holds the options the spec has been parsed with; nil
+	// means the defaults.
+	parser *Parser
 }
 
 // ParseError describes a problem parsing a timespec.
@@ -205,40 +321,625 @@ func (err *ParseError) Error() string {
 
 // Parse parses a timespec.
 //
+// In addition to the timespec grammar, Parse accepts RFC 3339
+// timestamps such as "2015-03-02T14:30:00Z", which resolve to exactly
+// the instant they denote regardless of the reference time.
+//
+// The date may also be given before the time, as in "tomorrow 10am",
+// or without any time at all, in which case the time is midnight.  The
+// RequireTime option rejects the latter.
+//
+// Increments may also be written as ISO 8601 durations, with or without
+// a leading "+", as in "now PT1H30M" or "noon + P1D".
+//
+// A time may carry seconds, either following another colon, as in
+// "14:30:05" and "2:30:05 pm", or as two more digits of a four-digit
+// time, as in "143005".
+//
+// A time may be followed by a numeric UTC offset, as in "14:00 +0200"
+// or "14:00 -05:00".
+//
+// In place of a time, the phrases "beginning of" and "end of" followed
+// by "day", "month" or "year" denote the first or last second of that
+// period, as in "end of month" or "beginning of day tomorrow".
+// "start of business day" and "end of business day", or "SOD" and "EOD"
+// for short, denote the times set with WithBusinessHours, as in "EOD
+// tomorrow".  "start of fiscal year" and "end of fiscal year" refer to
+// the fiscal year set with WithFiscalYearStart.
+//
 // If an error is returned, it is of type *ParseError.
 func Parse(timespec string) (*Timespec, error) {
+	return parse(nil, timespec)
+}
+
+// ParseStrict is like Parse, but fails on input it cannot make sense of
+// instead of ignoring it, as a Parser configured with Strict does.
+// Trailing whitespace is allowed; anything else following the timespec
+// is reported as an error at its first byte.
+func ParseStrict(timespec string) (*Timespec, error) {
+	return parse(strictParser, timespec)
+}
+
+// ParseTime parses only the time part of a timespec, such as "14:15" or
+// "noon".  The returned Timespec has no date or increment.
+//
+// If an error is returned, it is of type *ParseError.
+func ParseTime(s string) (*Timespec, error) {
+	return parseWith(nil, s, parseTime)
+}
+
+// ParseDate parses only the date part of a timespec, such as "Feb 02" or
+// "tomorrow".  The returned Timespec has no time or increment.
+//
+// If an error is returned, it is of type *ParseError.
+func ParseDate(s string) (*Timespec, error) {
+	return parseWith(nil, s, parseDate)
+}
+
+// ParseIncrement parses only the increment part of a timespec, such as
+// "+ 1 day" or "next week".  The returned Timespec has no time or date.
+//
+// If an error is returned, it is of type *ParseError.
+func ParseIncrement(s string) (*Timespec, error) {
+	return parseWith(nil, s, parseincrement)
+}
+
+// ParseAbsolute parses a timespec and replaces everything in it that
+// refers to now, such as "now", "tomorrow", weekdays and increments, by
+// the date and time it resolves to against now.  The result is
+// absolute, see IsAbsolute, and resolves to exactly that instant: the
+// hooks and offsets of the options it has been parsed with are not
+// applied a second time.
+//
+// If an error is returned, it is either a *ParseError or an error from
+// resolving the spec, see ResolveChecked.
+func ParseAbsolute(timespec string, now time.Time) (*Timespec, error) {
+	return parseAbsolute(nil, timespec, now)
+}
+
+func parseAbsolute(p *Parser, timespec string, now time.Time) (*Timespec, error) {
+	spec, err := parse(p, timespec)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := spec.resolve(now)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec.finalized(t), nil
+}
+
+// finalized returns a spec resolving to t, the time d resolves to, no
+// matter the time it is resolved against.
+func (d *Timespec) finalized(t time.Time) *Timespec {
+	return &Timespec{
+		instant:     t,
+		final:       true,
+		namedZone:   d.namedZone,
+		payload:     d.payload,
+		diagnostics: d.diagnostics,
+		parser:      d.parser,
+	}
+}
+
+// ParseAndResolve parses timespec and resolves it against now.
+//
+// If an error is returned, it is of type *ParseError.
+func ParseAndResolve(timespec string, now time.Time) (time.Time, error) {
+	return parseAndResolve(nil, timespec, now, nil)
+}
+
+// ParseAndResolveIn is like ParseAndResolve, but reads the date and time
+// of timespec as wall clock values in loc, unless timespec carries a
+// timezone of its own.  The result is in loc.
+func ParseAndResolveIn(timespec string, now time.Time, loc *time.Location) (time.Time, error) {
+	return parseAndResolve(nil, timespec, now, loc)
+}
+
+func parseAndResolve(p *Parser, timespec string, now time.Time, loc *time.Location) (time.Time, error) {
+	spec, err := parse(p, timespec)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if loc == nil {
+		return spec.Resolve(now), nil
+	}
+
+	if spec.zone == "" {
+		spec.location = loc
+	}
+
+	return spec.Resolve(now).In(loc), nil
+}
+
+func parse(p *Parser, timespec string) (*Timespec, error) {
+	if rfc3339Prefix.MatchString(timespec) {
+		return parseRFC3339(p, timespec)
+	}
+
+	return parseWith(p, timespec, parseTimespec)
+}
+
+// parseRFC3339 parses a complete RFC 3339 timestamp such as
+// "2015-03-02T14:30:00Z" into a spec resolving to exactly that instant.
+func parseRFC3339(p *Parser, timespec string) (*Timespec, error) {
+	t, err := time.Parse(time.RFC3339, timespec)
+	if err != nil {
+		return nil, p.parseError(timespec, 0, fmt.Sprintf("rfc3339: %s", err))
+	}
+
+	return &Timespec{instant: t, parser: p}, nil
+}
+
+// parseWith parses timespec using the given production.
+func parseWith(p *Parser, timespec string, production func(io.ByteScanner, *Timespec) error) (*Timespec, error) {
+	if strings.TrimSpace(timespec) == "" {
+		return nil, p.parseError(timespec, 0, "timespec: empty timespec")
+	}
+
 	buf := &buffer{src: timespec, pos: 0}
-	spec := &Timespec{}
-	err := parseTimespec(buf, spec)
+	spec := &Timespec{parser: p}
+	err := production(buf, spec)
 
 	if err != nil {
-		return nil, &ParseError{Src: timespec, Pos: buf.pos, Msg: err.Error()}
-	} else {
-		return spec, nil
+		return nil, p.parseError(timespec, buf.pos, err.Error())
+	}
+
+	if rest := strings.TrimSpace(timespec[buf.pos:]); rest != "" {
+		if spec.options().payload {
+			skip(buf, isspace)
+			spec.payload = buf.pos
+		} else if spec.options().strict {
+			skip(buf, isspace)
+			return nil, p.parseError(timespec, buf.pos, fmt.Sprintf("timespec: unexpected trailing input %q", rest))
+		} else {
+			warn(buf, spec, "ignored trailing input: %q", rest)
+			spec.errors = append(spec.errors, Diagnostic{Pos: buf.pos, Msg: fmt.Sprintf("timespec: unexpected trailing input %q", rest)})
+		}
 	}
+
+	return spec, nil
 }
 
 // Resolve converts a timespec to a time value, using the provided time
-// for resolving "now", "today" and "tomorrow".
+// for resolving "now", "today" and "tomorrow".  A timespec without a
+// date, or with the date "today", refers to the date of the provided
+// time; increments are applied after that date has been filled in.
+// Only a four-digit year given in d makes the result fall into an early
+// year such as 0001; a missing year is never taken to be year 0.
+//
+// Increments are applied to the date as moved by "tomorrow" or
+// "yesterday", one after the other in the order given, so that
+// "tomorrow + 1 month" on January 31 is March 1 rather than March 4,
+// the day after January 31 + 1 month.
+//
+// A day of the week refers to the next date falling on that day.  If
+// now falls on that day already, the date of now is used unless the
+// specified time on that date is before now, in which case the date one
+// week later is used.  "last" followed by a day of the week refers to the
+// most recent date before today falling on that day, so that "last
+// Friday" on a Friday is a week ago.  "next" followed by a day of the
+// week refers to the first date after today falling on that day, or to
+// that day in the following week under WithNextWeekdayMode(NextWeek).
+// "this" followed by a day of the week refers to that day in the week of
+// now, starting on Monday, even if it has passed already.  "this"
+// followed by a unit such as "week" or "month" refers to the current
+// one, that is to today, so that "noon this week" is noon today.
+//
+// A month and day without a year refer to the current year if that
+// date and time are later than now.  Otherwise the following year is
+// assumed, unless the month is the current month.  The LeapDayPolicy a
+// spec has been parsed with decides what happens if this rule picks a
+// non-leap year for February 29.
+//
+// Increments may carry any field out of its range, including below
+// zero.  Such values are normalized by borrowing from or carrying into
+// the next larger unit, so that 30 minutes before 00:10 is 23:40 on the
+// previous day.
 //
-// The resulting time is in UTC.
+// If d has been parsed with the RollToFuture option and consists only
+// of a time, a result at or before now is moved to the following day.
+//
+// A numeric UTC offset or a timezone found in the table given to
+// WithTimezoneTable makes the date and time be read as wall clock values
+// in that location; now is converted to it for filling in missing
+// fields.  As "now" is an instant, a timezone following it, as in "now
+// UTC" or "now + 1 day UTC", leaves the point in time alone and only
+// decides the location increments are applied in.
+//
+// The resulting time is in UTC, unless d names an IANA timezone such as
+// "Europe/Berlin", in which case it is in that location, or unless
+// changed by a hook given to WithPostResolve.  Resolve does not modify
+// d, so a spec may be resolved any number of times.
 func (d *Timespec) Resolve(now time.Time) time.Time {
+	t, _ := d.resolve(now)
+	return t
+}
+
+// ResolveChecked is like Resolve, but returns an error if d cannot be
+// resolved to a valid time according to the options it has been parsed
+// with.  This is the case for February 29 under the ErrorOnInvalid
+// LeapDayPolicy if no leap year has been inferred, and for results
+// beyond the horizon set with WithMaxHorizon.
+func (d *Timespec) ResolveChecked(now time.Time) (time.Time, error) {
+	return d.resolve(now)
+}
+
+// ResolveNoted is like Resolve, but also reports whether the date or
+// time given in d had to be normalized into a valid time, as for "Feb
+// 31", which resolves to early March.  Fields carried out of their range
+// by an increment, as in "Feb 28 + 1 day", do not count.
+func (d *Timespec) ResolveNoted(now time.Time) (time.Time, bool) {
+	given := d.clone()
+	given.increments, given.hasIncrement = 0, false
+	// midnight at the end of a day is given as the day it ends
+	given.endOfDay = false
+
+	unincremented, _ := given.resolveTime(now)
+	if loc := d.zoneLocation(); loc != nil {
+		unincremented = unincremented.In(loc)
+	}
+
+	return d.Resolve(now), d.normalized(unincremented)
+}
+
+// normalized reports whether t, which d resolves to without its
+// increment, differs from the date and time given in d.
+func (d *Timespec) normalized(t time.Time) bool {
+	if !d.instant.IsZero() || d.edge != edgeNone && (d.edgeUnit != Days || d.fiscal) {
+		return false
+	}
+
+	year, month, day := t.Date()
+	if d.year != 0 && d.year != year || d.month != 0 && d.month != month || d.day != 0 && d.day != day {
+		return true
+	}
+
+	if d.isNow || d.dateOnly || d.edge != edgeNone {
+		return false
+	}
+
+	hours, minutes, seconds := t.Clock()
+
+	return d.hours != hours || d.minutes != minutes || d.seconds != seconds
+}
+
+// resolve resolves d, working on a copy so that d itself is left as it
+// has been parsed.
+func (d *Timespec) resolve(now time.Time) (time.Time, error) {
+	t, err := d.clone().resolveTime(now)
+	if d.final {
+		return t, err
+	}
+
+	t = d.options().secondAlignment.align(t)
+
+	for _, hook := range d.options().postResolve {
+		t = hook(t)
+	}
+
+	if horizon := d.options().maxHorizon; err == nil && horizon > 0 && t.After(now.Add(horizon)) {
+		err = fmt.Errorf("resolve: %s is more than %s after %s",
+			t.Format(time.RFC3339), horizon, now.Format(time.RFC3339))
+	}
+
+	return t, err
+}
+
+// resolveTime resolves d without applying the hooks from
+// WithPostResolve.  It fills in the fields of d while doing so.
+func (d *Timespec) resolveTime(now time.Time) (time.Time, error) {
+	if !d.instant.IsZero() && d.namedZone {
+		return d.instant, nil
+	} else if !d.instant.IsZero() {
+		return d.instant.UTC(), nil
+	}
+
+	var err error
+	timeOnly := d.isTimeOnly()
+
+	if d.anchor != "" {
+		now = d.options().anchors[d.anchor]
+	}
+
+	loc := time.UTC
+	if zone := d.zoneLocation(); zone != nil {
+		loc = zone
+		now = now.In(loc)
+	} else if d.zone != "" {
+		// "UTC" or "GMT"
+		now = now.In(loc)
+	}
+
 	if d.isNow {
 		d.fromTime(now)
+	} else if d.isWeekday {
+		d.resolveWeekday(now)
+	} else if d.ordinal != 0 {
+		err = d.resolveOrdinalWeekday(now)
+	} else if d.year == 0 && d.month != 0 {
+		err = d.inferYear(now)
+	} else {
+		d.fillDate(now)
 	}
 
 	if d.isTomorrow {
 		d.day = d.day + 1
+	} else if d.isYesterday {
+		d.day = d.day - 1
+	}
+
+	d.day = d.day + d.dayOffset
+
+	if d.isTonight && !d.dateOnly && d.hours == 0 && d.minutes == 0 && d.seconds == 0 {
+		d.day = d.day + 1
+	} else if d.endOfDay {
+		d.day = d.day + 1
 	}
 
+	d.applyEdge()
 	d.addincrement()
 
-	return time.Date(d.year, d.month, d.day, d.hours, d.minutes, d.seconds, 0, time.UTC)
+	t := time.Date(d.year, d.month, d.day, d.hours, d.minutes, d.seconds, 0, loc)
+
+	if timeOnly && d.options().rollToFuture && !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	if d.namedZone {
+		return t, err
+	}
+
+	return t.UTC(), err
+}
+
+// zoneLocation returns the location the date and time of d are read in,
+// or nil if they are read in UTC while taking missing fields from now as
+// it is.
+func (d *Timespec) zoneLocation() *time.Location {
+	if d.location != nil {
+		return d.location
+	}
+
+	if offset := d.options().fixedOffset; offset != 0 && d.zone == "" {
+		return time.FixedZone("", int(offset/time.Second))
+	}
+
+	return nil
+}
+
+// fillDate takes the parts of the date missing from d from now, so
+// that d never resolves to year 0 just because no year has been given.
+func (d *Timespec) fillDate(now time.Time) {
+	year, month, day := now.Date()
+
+	if d.year == 0 {
+		d.year = year
+	}
+	if d.month == 0 {
+		d.month = month
+	}
+	if d.day == 0 {
+		d.day = day
+	}
+}
+
+// inferYear picks the year for a spec with a month and day but no year.
+func (d *Timespec) inferYear(now time.Time) error {
+	year := now.Year()
+	candidate := time.Date(year, d.month, d.day, d.hours, d.minutes, d.seconds, 0, now.Location())
+	if !candidate.After(now) && d.month != now.Month() {
+		year++
+	}
+
+	d.year = year
+
+	if d.month != time.February || d.day != 29 || isLeapYear(year) {
+		return nil
+	}
+
+	switch d.options().leapDayPolicy {
+	case NextValidYear:
+		for !isLeapYear(d.year) {
+			d.year++
+		}
+	case ErrorOnInvalid:
+		return fmt.Errorf("resolve: February 29 does not exist in %d", year)
+	}
+
+	return nil
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
 }
 
-// Time is a convenience function and the same as Resolve(time.Now()).
+// Time is a convenience function and the same as Resolve(time.Now()),
+// except that the monotonic clock reading is stripped from both the
+// reference time and the result.  Results of Time thus compare with ==
+// and Equal alike, just as results of Resolve do.
 func (d *Timespec) Time() time.Time {
-	return d.Resolve(time.Now())
+	return d.Resolve(time.Now().Round(0)).Round(0)
+}
+
+// ResolveFunc calls clock once and resolves d against the time it
+// returns, for code that passes clocks around as functions.
+func (d *Timespec) ResolveFunc(clock func() time.Time) time.Time {
+	return d.Resolve(clock())
+}
+
+// ResolveOnDate resolves d on the year, month and day of date, as read
+// in the location of date, instead of the date d refers to.  Only the
+// time of d and its increment are taken into account, so that "9am +
+// 30 minutes" resolves to 09:30 on date.  A spec without a time, such
+// as "tomorrow", resolves to midnight; "now" takes the time of date.
+func (d *Timespec) ResolveOnDate(date time.Time) time.Time {
+	onDate := d.clone()
+	onDate.isTomorrow = false
+	onDate.isYesterday = false
+	onDate.dayOffset = 0
+	onDate.isTonight = false
+	onDate.isWeekday = false
+	onDate.lastWeekday = false
+	onDate.nextWeekday = false
+	onDate.thisWeekday = false
+	onDate.thisPeriod = false
+	onDate.ordinal = 0
+	onDate.year, onDate.month, onDate.day = date.Date()
+
+	if onDate.isNow {
+		onDate.isNow = false
+		onDate.hours, onDate.minutes, onDate.seconds = date.Clock()
+	}
+
+	return onDate.Resolve(date)
+}
+
+// ResolveCivil resolves d as a spec given in loc: the date and time
+// of d are read as wall clock values in loc, missing fields are filled
+// in from now as seen in loc, and the result is returned in loc.  A
+// spec without a time, such as "Feb 12", resolves to at on that date,
+// so that it means local midnight for the zero TimeOfDay even where a
+// daylight saving time transition changes the offset of loc.  A
+// timezone given in d takes precedence over loc for reading d.
+func (d *Timespec) ResolveCivil(now time.Time, loc *time.Location, at TimeOfDay) time.Time {
+	civil := d.clone()
+	if civil.dateOnly {
+		civil.hours, civil.minutes, civil.seconds = at.Hours, at.Minutes, at.Seconds
+	}
+
+	return civil.ResolveInLocation(now, loc)
+}
+
+// ResolveInLocation is like Resolve, but reads the date and time of d
+// as wall clock values in loc, unless d specifies a timezone of its
+// own.  "now", "today" and "tomorrow" refer to now as seen in loc, and
+// the result is returned in loc, so that "09:00 tomorrow" is 9:00 local
+// time even across a change to or from daylight saving time.  For a now
+// in UTC, Resolve is the same as ResolveInLocation(now, time.UTC).
+func (d *Timespec) ResolveInLocation(now time.Time, loc *time.Location) time.Time {
+	local := d.clone()
+	if local.zone == "" {
+		local.location = loc
+	}
+
+	return local.Resolve(now).In(loc)
+}
+
+// ResolveUnix is the same as Resolve(now).Unix().
+func (d *Timespec) ResolveUnix(now time.Time) int64 {
+	return d.Resolve(now).Unix()
+}
+
+// Until returns the time from now until the time d resolves to, the
+// same as Resolve(now).Sub(now).  The result is negative if d resolves
+// to a time before now, as for "now - 1 hour".
+func (d *Timespec) Until(now time.Time) time.Duration {
+	return d.Resolve(now).Sub(now)
+}
+
+// Since returns the time elapsed since the time d resolves to, the same
+// as now.Sub(Resolve(now)) and thus the negation of Until.
+func (d *Timespec) Since(now time.Time) time.Duration {
+	return now.Sub(d.Resolve(now))
+}
+
+// ResolveLocal resolves d as entered by a user in the location of now,
+// returning the instant in UTC along with its Unix timestamp.  The date
+// and time of d are read as wall clock values in that location, unless
+// d specifies a timezone of its own, so that "9am tomorrow" is 9:00
+// local time even across a change to or from daylight saving time.
+func (d *Timespec) ResolveLocal(now time.Time) (time.Time, int64) {
+	t := d.ResolveInLocation(now, now.Location()).UTC()
+
+	return t, t.Unix()
+}
+
+// IsAbsolute reports whether d denotes the same point in time regardless
+// of the time it is resolved against.  This is the case for RFC 3339
+// timestamps and for specs with a full date, which must not be given
+// relative to now, as in "tomorrow" or "Friday".
+func (d *Timespec) IsAbsolute() bool {
+	if !d.instant.IsZero() {
+		return true
+	}
+
+	return !d.isNow && !d.isTomorrow && !d.isYesterday && d.dayOffset == 0 && !d.isWeekday && d.year != 0 && d.month != 0
+}
+
+// ResolveAbsolute resolves an absolute spec without a reference time.
+// It returns an error if d is not absolute, see IsAbsolute.
+func (d *Timespec) ResolveAbsolute() (time.Time, error) {
+	if !d.IsAbsolute() {
+		return time.Time{}, fmt.Errorf("resolve: timespec depends on the current time")
+	}
+
+	return d.resolve(time.Time{})
+}
+
+// ResolveBounded is like ResolveChecked, but additionally returns an
+// error if the result lies outside of [min, max], for rejecting input
+// such as times before the Unix epoch.  The result is returned along
+// with that error.
+func (d *Timespec) ResolveBounded(now, min, max time.Time) (time.Time, error) {
+	t, err := d.resolve(now)
+	if err != nil {
+		return t, err
+	}
+
+	if t.Before(min) || t.After(max) {
+		return t, fmt.Errorf("resolve: %s is outside of [%s, %s]",
+			t.Format(time.RFC3339), min.Format(time.RFC3339), max.Format(time.RFC3339))
+	}
+
+	return t, nil
+}
+
+// Bounds returns the earliest and latest times d resolves to for any
+// now in the calendar year of now, for analyzing specs whose result
+// depends on the time they are resolved against.  For an absolute spec
+// such as "Feb 12, 2015" both are the time it refers to.  For a spec
+// whose year is inferred, such as "Feb 12", the range is a year wide,
+// from the date in the year of now to the date in the following year.
+// Any other spec, such as "tomorrow" or "now + 1 hour", is relative to
+// now throughout, so that bounded is false.
+func (d *Timespec) Bounds(now time.Time) (earliest, latest time.Time, bounded bool) {
+	if !d.instant.IsZero() || d.IsAbsolute() {
+		t := d.Resolve(now)
+		return t, t, true
+	}
+
+	if !d.NeedsYearInference() {
+		return time.Time{}, time.Time{}, false
+	}
+
+	// resolving at the start of a year picks that year, as the date
+	// cannot have passed already
+	year := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+
+	return d.Resolve(year), d.Resolve(year.AddDate(1, 0, 0)), true
+}
+
+// clone returns a copy of d that can be modified independently of d.
+func (d *Timespec) clone() *Timespec {
+	c := *d
+	c.more = append([]Increment(nil), d.more...)
+	c.diagnostics = append([]Diagnostic(nil), d.diagnostics...)
+	c.errors = append([]Diagnostic(nil), d.errors...)
+
+	return &c
+}
+
+// options returns the parser options d has been parsed with.
+func (d *Timespec) options() *Parser {
+	if d.parser == nil {
+		return defaultParser
+	}
+
+	return d.parser
 }
 
 func (d *Timespec) fromTime(t time.Time) {
@@ -247,32 +948,171 @@ func (d *Timespec) fromTime(t time.Time) {
 }
 
 func (d *Timespec) isToday() bool {
-	return d.year == 0 && d.month == 0 && d.day == 0
+	return d.year == 0 && d.month == 0 && d.day == 0 && !d.isWeekday
+}
+
+// isTimeOnly reports whether d consists of nothing but a time.  An
+// instant such as "2015-03-01T09:00:00Z" names a date as well, and so
+// does an edge such as "end of month".
+func (d *Timespec) isTimeOnly() bool {
+	return !d.isNow && !d.isTomorrow && !d.isYesterday && !d.isTonight && d.dayOffset == 0 && d.isToday() && d.increments == 0 &&
+		d.instant.IsZero() && d.edge == edgeNone
 }
 
 func (d *Timespec) setToday() {
 	d.year = 0
 	d.month = 0
 	d.day = 0
+	d.isWeekday = false
+	d.lastWeekday = false
+	d.nextWeekday = false
+	d.thisWeekday = false
+	d.thisPeriod = false
+	d.ordinal = 0
+}
+
+// resolveWeekday sets the date of d to the next date falling on the
+// requested weekday.  If now falls on that weekday, the date of now is
+// used unless the time of d on that date is already before now.
+//
+// For "last" weekdays the most recent date before today falling on that
+// weekday is used instead, which is a week ago if now falls on it.  For
+// "next" weekdays it is the first date after today falling on that
+// weekday, or the one in the week after that of now, starting on Monday,
+// under WithNextWeekdayMode(NextWeek).  For "this" weekdays it is the
+// date falling on that weekday in the week of now, starting on Monday.
+func (d *Timespec) resolveWeekday(now time.Time) {
+	d.year, d.month, d.day = now.Date()
+
+	if d.lastWeekday {
+		d.day -= (int(now.Weekday())-int(d.weekday)+6)%7 + 1
+		return
+	}
+
+	if d.thisWeekday {
+		d.day += (int(d.weekday)+6)%7 - (int(now.Weekday())+6)%7
+		return
+	}
+
+	if d.nextWeekday && d.options().nextWeekdayMode == NextWeek {
+		// days until next Monday, then on to the requested weekday
+		d.day += 7 - (int(now.Weekday())+6)%7 + (int(d.weekday)+6)%7
+		return
+	} else if d.nextWeekday {
+		d.day += (int(d.weekday)-int(now.Weekday())+6)%7 + 1
+		return
+	}
+
+	d.day += (int(d.weekday) - int(now.Weekday()) + 7) % 7
+
+	t := time.Date(d.year, d.month, d.day, d.hours, d.minutes, d.seconds, 0, now.Location())
+	if t.Before(now) {
+		d.day += 7
+	}
+}
+
+// applyEdge moves d to the first or last instant of the day, month or
+// year requested by a "beginning of" or "end of" phrase.
+func (d *Timespec) applyEdge() {
+	if d.fiscal {
+		d.applyFiscalEdge()
+		return
+	}
+
+	switch d.edge {
+	case edgeBeginning:
+		d.hours, d.minutes, d.seconds = 0, 0, 0
+		if d.edgeUnit == Months || d.edgeUnit == Years {
+			d.day = 1
+		}
+		if d.edgeUnit == Years {
+			d.month = time.January
+		}
+	case edgeEnd:
+		d.hours, d.minutes, d.seconds = 23, 59, 59
+		switch d.edgeUnit {
+		case Months:
+			// day 0 of the following month is the last day of this one
+			d.month, d.day = d.month+1, 0
+		case Years:
+			d.month, d.day = time.December, 31
+		}
+	}
+}
+
+// applyFiscalEdge moves d to the first or last instant of the fiscal
+// year containing its date.
+func (d *Timespec) applyFiscalEdge() {
+	start, day := d.options().fiscalMonth, d.options().fiscalDay
+
+	year := d.year
+	if d.month < start || d.month == start && d.day < day {
+		year--
+	}
+
+	switch d.edge {
+	case edgeBeginning:
+		d.year, d.month, d.day = year, start, day
+		d.hours, d.minutes, d.seconds = 0, 0, 0
+	case edgeEnd:
+		// the day before the next fiscal year starts
+		d.year, d.month, d.day = year+1, start, day-1
+		d.hours, d.minutes, d.seconds = 23, 59, 59
+	}
 }
 
 func (d *Timespec) addincrement() {
-	switch d.unit {
-	case incrementMinutes:
-		d.minutes = d.minutes + d.increments
-	case incrementHours:
-		d.hours = d.hours + d.increments
-	case incrementDays:
-		d.day = d.day + d.increments
-	case incrementWeeks:
-		d.day = d.day + 7*d.increments
-	case incrementMonths:
-		d.month = d.month + time.Month(d.increments)
-	case incrementYears:
-		d.year = d.year + d.increments
+	increments := append([]Increment{{Count: d.increments, Unit: d.unit}}, d.more...)
+
+	// each increment applies to the date the date keywords and the
+	// previous increments lead to, as adding months and days does not
+	// commute
+	for _, increment := range increments {
+		d.normalize()
+		if d.options().businessDaysOnly && d.addBusinessDays(increment.Count, increment.Unit) {
+			continue
+		}
+
+		d.add(increment.Count, increment.Unit)
+	}
+}
+
+// add adds count periods of unit to the fields of d.
+func (d *Timespec) add(count int, unit Period) {
+	switch unit {
+	case Minutes:
+		d.minutes = d.minutes + count
+	case Hours:
+		d.hours = d.hours + count
+	case Days:
+		d.day = d.day + count
+	case Weeks:
+		d.day = d.day + 7*count
+	case Months:
+		d.month = d.month + time.Month(count)
+	case Years:
+		d.year = d.year + count
+	case Fortnights:
+		d.day = d.day + 14*count
+	case Quarters:
+		d.month = d.month + time.Month(3*count)
+	case Decades:
+		d.year = d.year + 10*count
+	case Centuries:
+		d.year = d.year + 100*count
+	case Seconds:
+		d.seconds = d.seconds + count
 	}
 }
 
+// normalize brings the fields of d back into their ranges, so that
+// "Feb 30" becomes "Mar 2".
+func (d *Timespec) normalize() {
+	t := time.Date(d.year, d.month, d.day, d.hours, d.minutes, d.seconds, 0, time.UTC)
+	d.year, d.month, d.day = t.Date()
+	d.hours, d.minutes, d.seconds = t.Clock()
+}
+
 // Buffer holds the string to parse.
 //
 // The only error any methods can return is io.EOF.  Additionally it
@@ -281,21 +1121,38 @@ func (d *Timespec) addincrement() {
 type buffer struct {
 	src string
 	pos int
+	// eof is set if the last read hit the end of src, which leaves
+	// nothing to unread.
+	eof bool
 }
 
 func (buf *buffer) ReadByte() (byte, error) {
 	if buf.pos >= len(buf.src) {
+		buf.eof = true
 		return 0, io.EOF
 	}
 
 	c := buf.src[buf.pos]
 
 	buf.pos++
+	buf.eof = false
 
 	return c, nil
 }
 
+// UnreadByte backs up over the byte returned by the last call to
+// ReadByte.  After a read that hit the end of src there is no such byte,
+// and UnreadByte leaves the position alone: productions peek at the next
+// byte by reading and unreading it, and backing up over the last byte of
+// src instead would report errors at the end of the input one byte too
+// early, as for the missing seconds of "14:15:", and break ISO 8601
+// durations such as "P1D".
 func (buf *buffer) UnreadByte() error {
+	if buf.eof {
+		buf.eof = false
+		return nil
+	}
+
 	if buf.pos <= 0 {
 		return nil
 	}
@@ -305,63 +1162,178 @@ func (buf *buffer) UnreadByte() error {
 	return nil
 }
 
-type incrementType int
+// offset returns the current position of in if it is a buffer, or 0.
+func offset(in io.ByteScanner) int {
+	if buf, ok := in.(*buffer); ok {
+		return buf.pos
+	}
 
-const (
-	incrementMinutes incrementType = iota
-	incrementHours
-	incrementDays
-	incrementWeeks
-	incrementMonths
-	incrementYears
-)
+	return 0
+}
 
-var (
-	monthNames = []*regexp.Regexp{
-		regexp.MustCompile("Jan(uary)?"),
-		regexp.MustCompile("Feb(ruary)?"),
-		regexp.MustCompile("Mar(ch)?"),
-		regexp.MustCompile("Apr(il)?"),
-		regexp.MustCompile("May"),
-		regexp.MustCompile("June?"),
-		regexp.MustCompile("July?"),
-		regexp.MustCompile("Aug(ust)?"),
-		regexp.MustCompile("Sep(tember)?"),
-		regexp.MustCompile("Oct(ober)?"),
-		regexp.MustCompile("Nov(ember)?"),
-		regexp.MustCompile("Dec(ember)?"),
-	}
-	dayNames = []*regexp.Regexp{
-		regexp.MustCompile("Mon(day)?"),
-		regexp.MustCompile("Tue(sday)?"),
-		regexp.MustCompile("Wed(nesday)?"),
-		regexp.MustCompile("Thu(rsday)?"),
-		regexp.MustCompile("Fri(day)?"),
-		regexp.MustCompile("Sat(urday)?"),
-		regexp.MustCompile("Sun(day)?"),
-	}
-	periodNames = []*regexp.Regexp{
-		regexp.MustCompile("minutes?"),
-		regexp.MustCompile("hours?"),
-		regexp.MustCompile("days?"),
-		regexp.MustCompile("weeks?"),
-		regexp.MustCompile("months?"),
-		regexp.MustCompile("years?"),
+// lookingAtWord reports whether in is positioned at word, ignoring
+// case, if it is a buffer.  Other scanners cannot look ahead far enough
+// and always yield false.
+func lookingAtWord(in io.ByteScanner, word string) bool {
+	buf, ok := in.(*buffer)
+	if !ok || len(buf.src)-buf.pos < len(word) {
+		return false
 	}
-)
 
-type charclass func(r byte) bool
+	end := buf.pos + len(word)
+	if !strings.EqualFold(buf.src[buf.pos:end], word) {
+		return false
+	}
 
-func isdigit(r byte) bool {
-	return r >= '0' && r <= '9'
+	return end == len(buf.src) || !isalpha(buf.src[end])
 }
 
-func isspace(r byte) bool {
-	return r == ' ' || r == '\n' || r == '\t'
+// rewind moves in back to pos if it is a buffer.  Other scanners are
+// left alone, as they cannot back up over more than one byte.
+func rewind(in io.ByteScanner, pos int) {
+	if buf, ok := in.(*buffer); ok {
+		buf.pos = pos
+		buf.eof = false
+	}
 }
 
-func nospace(r byte) bool {
-	return !isspace(r)
+type edgeType int
+
+const (
+	edgeNone edgeType = iota
+	edgeBeginning
+	edgeEnd
+)
+
+// A Period is the unit of an increment, such as "week" in "next week".
+type Period int
+
+// The periods understood in increments.
+const (
+	Minutes Period = iota
+	Hours
+	Days
+	Weeks
+	Months
+	Years
+	Fortnights
+	Quarters
+	Decades
+	Centuries
+	Seconds
+)
+
+// periodWords holds the singular canonical name of every Period.
+var periodWords = []string{"minute", "hour", "day", "week", "month", "year", "fortnight", "quarter", "decade", "century", "second"}
+
+// plural returns the plural of the period name word.
+func plural(word string) string {
+	if word == "century" {
+		return "centuries"
+	}
+
+	return word + "s"
+}
+
+// String returns the canonical plural name of p, such as "minutes".
+func (p Period) String() string {
+	if p < 0 || int(p) >= len(periodWords) {
+		return fmt.Sprintf("Period(%d)", int(p))
+	}
+
+	return plural(periodWords[p])
+}
+
+// MarshalText returns the canonical name of p.
+func (p Period) MarshalText() ([]byte, error) {
+	if p < 0 || int(p) >= len(periodWords) {
+		return nil, fmt.Errorf("timespec: invalid period %d", int(p))
+	}
+
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText sets p to the period named by text, which may be the
+// singular or plural name of a period, such as "week" or "weeks".
+func (p *Period) UnmarshalText(text []byte) error {
+	name := string(text)
+
+	for index, word := range periodWords {
+		if name == word || name == plural(word) {
+			*p = Period(index)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("timespec: invalid period %q", text)
+}
+
+var (
+	rfc3339Prefix = regexp.MustCompile(`^\d{4}-\d\d-\d\dT`)
+	// monthsByName maps the lower case names of months, in full
+	// and abbreviated, to their numbers.
+	monthsByName = map[string]int{
+		"jan": 1, "january": 1,
+		"feb": 2, "february": 2,
+		"mar": 3, "march": 3,
+		"apr": 4, "april": 4,
+		"may": 5,
+		"jun": 6, "june": 6,
+		"jul": 7, "july": 7,
+		"aug": 8, "august": 8,
+		"sep": 9, "sept": 9, "september": 9,
+		"oct": 10, "october": 10,
+		"nov": 11, "november": 11,
+		"dec": 12, "december": 12,
+	}
+	// daysByName maps the lower case names of the days of the week to
+	// their index counting from Monday.
+	daysByName = map[string]int{
+		"mon": 0, "monday": 0,
+		"tue": 1, "tues": 1, "tuesday": 1,
+		"wed": 2, "wednesday": 2,
+		"thu": 3, "thur": 3, "thurs": 3, "thursday": 3,
+		"fri": 4, "friday": 4,
+		"sat": 5, "saturday": 5,
+		"sun": 6, "sunday": 6,
+	}
+	// periodsByName maps the singular and plural names of periods to
+	// their Period.
+	periodsByName = map[string]Period{
+		"minute": Minutes, "minutes": Minutes,
+		"hour": Hours, "hours": Hours,
+		"day": Days, "days": Days,
+		"week": Weeks, "weeks": Weeks,
+		"month": Months, "months": Months,
+		"year": Years, "years": Years,
+		"fortnight": Fortnights, "fortnights": Fortnights,
+		"quarter": Quarters, "quarters": Quarters,
+		"decade": Decades, "decades": Decades,
+		"century": Centuries, "centuries": Centuries,
+		"second": Seconds, "seconds": Seconds,
+	}
+	numberWords = []string{
+		"one", "two", "three", "four", "five", "six",
+		"seven", "eight", "nine", "ten", "eleven", "twelve",
+	}
+)
+
+type charclass func(r byte) bool
+
+func isdigit(r byte) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isalpha(r byte) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isspace(r byte) bool {
+	return r == ' ' || r == '\n' || r == '\t'
+}
+
+func nospace(r byte) bool {
+	return !isspace(r)
 }
 
 func skip(in io.ByteScanner, class charclass) byte {
@@ -373,7 +1345,9 @@ func skip(in io.ByteScanner, class charclass) byte {
 		c, err = in.ReadByte()
 	}
 
-	in.UnreadByte()
+	if err == nil {
+		in.UnreadByte()
+	}
 
 	return c
 }
@@ -385,13 +1359,23 @@ func peek(in io.ByteScanner) byte {
 		panic(err)
 	}
 
-	in.UnreadByte()
+	if err == nil {
+		in.UnreadByte()
+	}
 
 	return c
 }
 
+// expect appends the next byte of in to out if it belongs to class and
+// reports whether it did.  A byte of another class is left unread and
+// returned.  At the end of the input 0 is returned and nothing is
+// unread, as a bufio.Reader would otherwise back up over the byte
+// before.
 func expect(in io.ByteScanner, out *[]byte, class charclass) (byte, bool) {
-	c, _ := in.ReadByte()
+	c, err := in.ReadByte()
+	if err != nil {
+		return 0, false
+	}
 
 	if !class(c) {
 		in.UnreadByte()
@@ -412,387 +1396,1866 @@ func expectBytes(in io.ByteScanner, s []byte) (string, bool) {
 
 		if c != expected {
 			in.UnreadByte()
-			return string(buf), false
+			return string(buf), false
+		}
+	}
+
+	return "", true
+}
+
+func any(in io.ByteScanner, out *[]byte, class charclass) {
+	c, err := in.ReadByte()
+	if err != nil {
+		return
+	}
+
+	for class(c) && err == nil {
+		*out = append(*out, c)
+		c, err = in.ReadByte()
+	}
+
+	if err == nil {
+		in.UnreadByte()
+	}
+}
+
+// expectN appends the next n bytes of in to out if they belong to
+// class, returning the last one.  On a byte of another class or the end
+// of the input it stops, returning what expect returned along with
+// false; in is then positioned right after the bytes appended to out, so
+// callers can tell how many they got and carry on from there.
+func expectN(n int, in io.ByteScanner, out *[]byte, class charclass) (byte, bool) {
+	var c byte
+
+	for i := 0; i < n; i++ {
+		var ok bool
+		if c, ok = expect(in, out, class); !ok {
+			return c, false
+		}
+	}
+
+	return c, true
+}
+
+func parseTimespec(in io.ByteScanner, spec *Timespec) error {
+	c := peek(in)
+	if c == 0 {
+		return fmt.Errorf("timespec: unexpected EOF")
+	}
+
+	var err error
+
+	if parseNowSynonym(in, spec) {
+		return parseNowIncrement(in, spec)
+	}
+
+	if spec.options().relativeByDefault {
+		if ok, err := parseImpliedNow(in, spec); ok || err != nil {
+			return err
+		}
+	}
+
+	if c == '+' {
+		if ok, err := parseFromPhrase(in, spec); ok || err != nil {
+			return err
+		}
+	}
+
+	if c == '+' && spec.options().anchors != nil {
+		return parseAnchored(in, spec)
+	}
+
+	// A date may precede the time, as in "tomorrow 10am", or stand on
+	// its own, in which case the time defaults to midnight.
+	dateFirst := c == 't' || c == 'l' || c == 'i' || c == 'y' || c == 'd' || (c >= 'A' && c <= 'Z' && c != 'P')
+	if lookingAtWord(in, "EOD") || lookingAtWord(in, "SOD") {
+		dateFirst = false
+	} else if lookingAtWord(in, "on") || lookingAtDateName(in) || lookingAtNextWeekday(in) || lookingAtOrdinalWeekday(in) || lookingAtISODate(in) {
+		dateFirst = true
+	}
+	if dateFirst {
+		if err = parseDate(in, spec); err != nil {
+			return err
+		}
+
+		c = skip(in, isspace)
+		if phrase, at := findTimeOfDay(in, spec); phrase != "" {
+			parseTimeOfDay(in, spec, phrase, at)
+		} else if c == 'n' {
+			// "noon" or the "next" of an increment
+			in.ReadByte()
+			if peek(in) == 'o' {
+				err = parseNoonRest(in, spec, "n")
+			} else {
+				in.UnreadByte()
+				spec.dateOnly = true
+			}
+		} else if isdigit(c) || c == 'm' || c == 'b' || c == 'e' || c == 's' || lookingAtWord(in, "EOD") || lookingAtWord(in, "SOD") {
+			err = parseTime(in, spec)
+		} else {
+			spec.dateOnly = true
+		}
+
+		if spec.dateOnly && spec.options().requireTime {
+			return fmt.Errorf("timespec: expected a time")
+		}
+	} else if c == 'n' {
+		// "now" and "noon" share their first two bytes
+		actual, ok := expectBytes(in, []byte("no"))
+		if !ok {
+			return fmt.Errorf("timespec: expected %q, got %q", "now", actual)
+		}
+
+		if peek(in) == 'o' {
+			err = parseNoonRest(in, spec, "no")
+		} else {
+			actual, ok = expectBytes(in, []byte("w"))
+			if !ok {
+				return fmt.Errorf("timespec: expected %q, got %q", "now", "no"+actual)
+			}
+
+			return parseNowIncrement(in, spec)
+		}
+	} else {
+		err = parseTime(in, spec)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if spec.options().noiseWords {
+		parseNoiseWord(in)
+	}
+
+	if !dateFirst && skip(in, isspace) != 0 {
+		start := offset(in)
+		err = parseDate(in, spec)
+		if err != nil && spec.options().strict {
+			return err
+		} else if err != nil {
+			ignore(in, spec, "date", err)
+			spec.year = 0
+			spec.month = 0
+			spec.day = 0
+			skipIgnored(in, spec, start)
+			if spec.options().collect {
+				skipToIncrement(in)
+			}
+		}
+	}
+
+	return parseOptionalIncrement(in, spec)
+}
+
+// noiseWords lists the words accepted for emphasis after a time when
+// parsing with NoiseWords.
+var noiseWords = []string{"sharp", "exactly"}
+
+// parseNoiseWord consumes one of noiseWords, ignoring case, along with
+// the space preceding it.  Any other input is left alone.
+func parseNoiseWord(in io.ByteScanner) {
+	start := offset(in)
+	skip(in, isspace)
+
+	for _, word := range noiseWords {
+		if lookingAtWord(in, word) {
+			for i := 0; i < len(word); i++ {
+				in.ReadByte()
+			}
+			return
+		}
+	}
+
+	rewind(in, start)
+}
+
+// parseNowIncrement parses the increment following "now" or one of its
+// synonyms.
+func parseNowIncrement(in io.ByteScanner, spec *Timespec) error {
+	spec.isNow = true
+
+	return parseOptionalIncrement(in, spec)
+}
+
+// parseFromPhrase parses an increment followed by "from now", "from
+// today" or "from tomorrow", as in "+ 3 days from tomorrow", and
+// reports whether it found one.  Other input starting with an increment
+// is left alone.
+func parseFromPhrase(in io.ByteScanner, spec *Timespec) (bool, error) {
+	start := offset(in)
+
+	err := parseincrement(in, spec)
+	if err == errIncrementsDisabled || err == errIncrementTooLarge {
+		return true, err
+	}
+
+	word := []byte{}
+	skip(in, isspace)
+	any(in, &word, isalpha)
+
+	if err != nil || string(word) != "from" {
+		rewind(in, start)
+		spec.increments, spec.unit, spec.hasIncrement = 0, 0, false
+		return false, nil
+	}
+
+	word = word[:0]
+	skip(in, isspace)
+	any(in, &word, isalpha)
+
+	switch string(word) {
+	case "now":
+		spec.isNow = true
+	case "today":
+		spec.dateOnly = true
+	case "tomorrow":
+		spec.isTomorrow, spec.dateOnly = true, true
+	default:
+		return true, fmt.Errorf("from: expected \"now\", \"today\" or \"tomorrow\", got %q", word)
+	}
+
+	if spec.dateOnly && spec.options().requireTime {
+		return true, fmt.Errorf("timespec: expected a time")
+	}
+
+	return true, parseTrailingTimeZone(in, spec)
+}
+
+// parseOptionalIncrement parses the increment ending a timespec, which
+// a timezone may precede or follow.  An invalid increment is ignored,
+// leaving a diagnostic, unless parsing with Strict.
+func parseOptionalIncrement(in io.ByteScanner, spec *Timespec) error {
+	if err := parseTrailingTimeZone(in, spec); err != nil {
+		return err
+	}
+
+	start := offset(in)
+	err := parseincrement(in, spec)
+	if err == errIncrementsDisabled || err == errIncrementTooLarge || (err != nil && spec.options().strict) {
+		return err
+	} else if err != nil {
+		ignore(in, spec, "increment", err)
+		spec.increments, spec.hasIncrement = 0, false
+		skipIgnored(in, spec, start)
+	} else if err := parseMoreIncrements(in, spec); err != nil {
+		return err
+	}
+
+	return parseTrailingTimeZone(in, spec)
+}
+
+// parseMoreIncrements parses the increments following the first one, as
+// in "now + 1 day + 2 hours".  An invalid increment is ignored like the
+// first one, keeping those before it.
+func parseMoreIncrements(in io.ByteScanner, spec *Timespec) error {
+	for spec.hasIncrement && lookingAtIncrement(in, spec) {
+		start := offset(in)
+		count, unit := spec.increments, spec.unit
+
+		err := parseincrement(in, spec)
+		if err == errIncrementTooLarge || (err != nil && spec.options().strict) {
+			return err
+		}
+
+		if err == nil {
+			spec.more = append(spec.more, Increment{Count: spec.increments, Unit: spec.unit})
+		}
+
+		spec.increments, spec.unit = count, unit
+
+		if err != nil {
+			ignore(in, spec, "increment", err)
+			skipIgnored(in, spec, start)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// lookingAtIncrement reports whether in is positioned at the start of
+// an increment, skipping any spaces preceding it.
+func lookingAtIncrement(in io.ByteScanner, spec *Timespec) bool {
+	c := skip(in, isspace)
+
+	return c == '+' || c == '-' || c == 'n' || (c == 'p' && spec.options().wordIncrements)
+}
+
+// parseTrailingTimeZone parses a timezone ending a timespec, as in
+// "noon Feb 12 UTC" or "now + 1 day UTC", unless the time has already
+// been followed by one.  Numeric offsets are only recognized after the
+// time, where they cannot be mistaken for an increment.
+func parseTrailingTimeZone(in io.ByteScanner, spec *Timespec) error {
+	if spec.zone != "" || !isalpha(skip(in, isspace)) {
+		return nil
+	}
+
+	return parseOptionalTimeZone(in, spec)
+}
+
+// parseImpliedNow parses an increment standing in for "now" plus that
+// increment, as in "90 minutes" or "in 90 minutes", and reports whether
+// it found one.  Any other input is left alone.
+func parseImpliedNow(in io.ByteScanner, spec *Timespec) (bool, error) {
+	start := offset(in)
+
+	word := []byte{}
+	any(in, &word, isalpha)
+	if string(word) == "in" {
+		skip(in, isspace)
+	} else {
+		rewind(in, start)
+	}
+
+	digits := []byte{}
+	any(in, &digits, isdigit)
+
+	period := []byte{}
+	skip(in, isspace)
+	any(in, &period, isalpha)
+
+	unit := findPeriod(period)
+	if len(digits) == 0 || unit == -1 {
+		rewind(in, start)
+		return false, nil
+	}
+
+	count, err := parseCount(digits)
+	if err != nil {
+		return false, err
+	}
+
+	if err := checkIncrement(count, Period(unit)); err != nil {
+		return false, err
+	}
+
+	spec.isNow = true
+	spec.increments, spec.unit = int(count), Period(unit)
+	spec.hasIncrement = true
+
+	// further increments follow as they would after "now"
+	if err := parseMoreIncrements(in, spec); err != nil {
+		return true, err
+	}
+
+	return true, parseTrailingTimeZone(in, spec)
+}
+
+// parseNowSynonym consumes a word configured with WithNowSynonyms and
+// reports whether it found one.  Any other input is left alone.
+func parseNowSynonym(in io.ByteScanner, spec *Timespec) bool {
+	synonyms := spec.options().nowSynonyms
+	if len(synonyms) == 0 {
+		return false
+	}
+
+	start := offset(in)
+	buf := []byte{}
+	any(in, &buf, isalpha)
+
+	for _, synonym := range synonyms {
+		if strings.EqualFold(synonym, string(buf)) {
+			return true
+		}
+	}
+
+	rewind(in, start)
+	return false
+}
+
+// MaxIncrement is the largest increment accepted, counted in the field
+// it changes: weeks and fortnights count their days, quarters their
+// months and decades and centuries their years, so that at most 1000000
+// centuries are accepted.  It is small enough for any increment to be
+// applied without overflowing an int, even on 32-bit platforms.
+const MaxIncrement = 100000000
+
+// errIncrementTooLarge is returned for increments exceeding
+// MaxIncrement.  Unlike other problems with an increment it makes
+// parsing fail.
+var errIncrementTooLarge = fmt.Errorf("increment: count exceeds the maximum of %d", MaxIncrement)
+
+// parseCount converts the digits in buf to an increment count.
+func parseCount(buf []byte) (int64, error) {
+	count, err := strconv.ParseInt(string(buf), 10, 64)
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		return 0, errIncrementTooLarge
+	} else if err != nil {
+		return 0, fmt.Errorf("increment: %s", err)
+	}
+
+	if count > MaxIncrement {
+		return 0, errIncrementTooLarge
+	}
+
+	return count, nil
+}
+
+// checkIncrement returns errIncrementTooLarge if count periods of unit
+// exceed MaxIncrement once add multiplies them out.
+func checkIncrement(count int64, unit Period) error {
+	scale := int64(1)
+	switch unit {
+	case Weeks:
+		scale = 7
+	case Fortnights:
+		scale = 14
+	case Quarters:
+		scale = 3
+	case Decades:
+		scale = 10
+	case Centuries:
+		scale = 100
+	}
+
+	if count > MaxIncrement/scale {
+		return errIncrementTooLarge
+	}
+
+	return nil
+}
+
+// groupedDigits reads a count whose digits may be grouped by commas or
+// underscores, as in "1,000", into out without the separators.
+func groupedDigits(in io.ByteScanner, out *[]byte) error {
+	any(in, out, isdigit)
+
+	for len(*out) > 0 {
+		c, err := in.ReadByte()
+		if err != nil {
+			return nil
+		}
+
+		if c != ',' && c != '_' {
+			in.UnreadByte()
+			return nil
+		}
+
+		if !isdigit(peek(in)) {
+			return fmt.Errorf("increment: expected a digit after %q", c)
+		}
+
+		any(in, out, isdigit)
+	}
+
+	return nil
+}
+
+// errIncrementsDisabled is returned for increments when parsing with
+// the NoIncrements option.
+var errIncrementsDisabled = fmt.Errorf("increment: increments are not allowed")
+
+func parseincrement(in io.ByteScanner, spec *Timespec) error {
+	skip(in, isspace)
+
+	if spec.options().afterNext {
+		if ok, err := parseAfterNext(in, spec); ok || err != nil {
+			return err
+		}
+	}
+
+	c, _ := in.ReadByte()
+
+	if c == 0 {
+		return nil
+	}
+
+	if spec.options().noIncrements && (c == 'n' || c == '+' || c == '-' || c == 'P' || (c == 'p' && spec.options().wordIncrements)) {
+		in.UnreadByte()
+		return errIncrementsDisabled
+	}
+
+	if c == 'P' {
+		return parseISODuration(in, spec)
+	}
+
+	if c == 'n' {
+		in.UnreadByte()
+		actual, ok := expectBytes(in, []byte("next"))
+		if !ok {
+			return fmt.Errorf("increment: expected \"next\", got %q", actual)
+		}
+
+		spec.increments = 1
+	} else if c == '+' || c == '-' || (c == 'p' && spec.options().wordIncrements) {
+		if c == 'p' {
+			in.UnreadByte()
+			actual, ok := expectBytes(in, []byte("plus"))
+			if !ok {
+				return fmt.Errorf("increment: expected \"plus\", got %q", actual)
+			}
+		}
+
+		buf := []byte{}
+		skip(in, isspace)
+		if peek(in) == 'P' {
+			in.ReadByte()
+			err := parseISODuration(in, spec)
+			if c == '-' {
+				spec.increments = -spec.increments
+			}
+			return err
+		}
+
+		if spec.options().wordIncrements && !isdigit(peek(in)) {
+			any(in, &buf, nospace)
+			count := findNumberWord(buf)
+			if count == -1 {
+				return fmt.Errorf("increment: invalid number: %q", buf)
+			}
+
+			spec.increments = count
+		} else {
+			if spec.options().digitGrouping {
+				if err := groupedDigits(in, &buf); err != nil {
+					return err
+				}
+			} else {
+				any(in, &buf, isdigit)
+			}
+
+			count, err := parseCount(buf)
+			if err != nil {
+				return err
+			}
+
+			spec.increments = int(count)
+		}
+	} else {
+		return fmt.Errorf("increment: expected '+' or '-', got '%c'", c)
+	}
+
+	// a hyphen may take the place of the space before the unit, as in
+	// "+1-day" or "next-week"
+	if peek(in) == '-' {
+		in.ReadByte()
+	}
+
+	buf := []byte{}
+	skip(in, isspace)
+	any(in, &buf, nospace)
+
+	// "next" may be followed by a spelled out count: "next two weeks"
+	if c == 'n' && spec.options().wordIncrements {
+		if count := findNumberWord(buf); count != -1 {
+			spec.increments = count
+			buf = buf[:0]
+			skip(in, isspace)
+			any(in, &buf, nospace)
+		}
+	}
+
+	period := findPeriod(buf)
+	if len(buf) == 0 && c != 'n' && spec.options().defaultUnitSet {
+		period = int(spec.options().defaultUnit)
+	} else if period == -1 {
+		return fmt.Errorf("period: invalid period: %q", buf)
+	}
+
+	if err := checkIncrement(int64(spec.increments), Period(period)); err != nil {
+		return err
+	}
+
+	spec.unit = Period(period)
+	spec.hasIncrement = true
+
+	if c == '-' {
+		spec.increments = -spec.increments
+	}
+
+	return nil
+}
+
+// parseAfterNext parses "<period> after next" or "after next <period>",
+// optionally preceded by "the", as an increment of 2, and reports
+// whether it found one.  Any other input is left alone.
+func parseAfterNext(in io.ByteScanner, spec *Timespec) (bool, error) {
+	start := offset(in)
+
+	words := []string{}
+	for i := 0; i < 4; i++ {
+		word := []byte{}
+		skip(in, isspace)
+		any(in, &word, isalpha)
+		if len(word) == 0 || (i == 0 && string(word) == "the") {
+			continue
+		}
+		words = append(words, string(word))
+		if len(words) == 3 {
+			break
+		}
+	}
+
+	name := ""
+	if len(words) == 3 && words[0] == "after" && words[1] == "next" {
+		name = words[2]
+	} else if len(words) == 3 && words[1] == "after" && words[2] == "next" {
+		name = words[0]
+	}
+
+	// period names must match exactly, so that "Friday after next" is
+	// not taken for "day after next"
+	var unit Period
+	if err := unit.UnmarshalText([]byte(name)); err != nil {
+		rewind(in, start)
+		return false, nil
+	}
+
+	if spec.options().noIncrements {
+		rewind(in, start)
+		return false, errIncrementsDisabled
+	}
+
+	spec.increments, spec.unit = 2, unit
+	spec.hasIncrement = true
+
+	return true, nil
+}
+
+// lookingAtAfterNext reports whether in is positioned at an increment
+// parseAfterNext accepts, without consuming it.
+func lookingAtAfterNext(in io.ByteScanner, spec *Timespec) bool {
+	start := offset(in)
+	ok, _ := parseAfterNext(in, &Timespec{parser: spec.parser})
+	rewind(in, start)
+
+	return ok
+}
+
+// parseISODuration parses the remainder of an ISO 8601 duration such as
+// "P1D" or "PT1H30M", whose leading 'P' has already been read.
+//
+// The duration is converted to a single increment in the largest unit
+// that represents it exactly.  Seconds are not supported, neither are
+// durations mixing years or months with weeks, days or times.
+func parseISODuration(in io.ByteScanner, spec *Timespec) error {
+	var months, minutes int64
+	inTime, seen := false, false
+
+	for {
+		c, _ := in.ReadByte()
+		if c == 'T' && !inTime {
+			inTime = true
+			continue
+		}
+
+		if !isdigit(c) {
+			in.UnreadByte()
+			break
+		}
+
+		buf := []byte{c}
+		any(in, &buf, isdigit)
+		n, err := parseCount(buf)
+		if err != nil {
+			return err
+		}
+
+		designator, _ := in.ReadByte()
+		switch {
+		case !inTime && designator == 'Y':
+			months += 12 * n
+		case !inTime && designator == 'M':
+			months += n
+		case !inTime && designator == 'W':
+			minutes += 7 * 24 * 60 * n
+		case !inTime && designator == 'D':
+			minutes += 24 * 60 * n
+		case inTime && designator == 'H':
+			minutes += 60 * n
+		case inTime && designator == 'M':
+			minutes += n
+		default:
+			return fmt.Errorf("duration: unsupported designator %q", designator)
+		}
+
+		seen = true
+	}
+
+	if !seen {
+		return fmt.Errorf("duration: expected a number")
+	}
+
+	if months != 0 && minutes != 0 {
+		return fmt.Errorf("duration: cannot combine years or months with other units")
+	}
+
+	var count int64
+	switch {
+	case months != 0 && months%12 == 0:
+		count, spec.unit = months/12, Years
+	case months != 0:
+		count, spec.unit = months, Months
+	case minutes%(7*24*60) == 0:
+		count, spec.unit = minutes/(7*24*60), Weeks
+	case minutes%(24*60) == 0:
+		count, spec.unit = minutes/(24*60), Days
+	case minutes%60 == 0:
+		count, spec.unit = minutes/60, Hours
+	default:
+		count, spec.unit = minutes, Minutes
+	}
+
+	if err := checkIncrement(count, spec.unit); err != nil {
+		return err
+	}
+
+	spec.increments = int(count)
+	spec.hasIncrement = true
+
+	return nil
+}
+
+// findPeriod returns the Period named by buf, ignoring case, or -1 if
+// buf does not name one.
+func findPeriod(buf []byte) int {
+	if period, ok := periodsByName[strings.ToLower(string(buf))]; ok {
+		return int(period)
+	}
+
+	return -1
+}
+
+// findNumberWord returns the value of a spelled out number between one
+// and twelve, or -1 if buf is not such a number.
+func findNumberWord(buf []byte) int {
+	for index, word := range numberWords {
+		if string(buf) == word {
+			return index + 1
+		}
+	}
+
+	return -1
+}
+
+// lookingAtNextWeekday reports whether in is positioned at "next"
+// followed by a day of the week, as in "next Tuesday", without consuming
+// any input.
+func lookingAtNextWeekday(in io.ByteScanner) bool {
+	if !lookingAtWord(in, "next") {
+		return false
+	}
+
+	start := offset(in)
+	defer rewind(in, start)
+
+	word := []byte{}
+	any(in, &word, isalpha)
+	skip(in, isspace)
+	word = word[:0]
+	any(in, &word, isalpha)
+
+	return findDayOfWeek(word) != -1
+}
+
+// lookingAtDateName reports whether in is positioned at the name of a
+// month or a day of the week, in any case, without consuming it.
+func lookingAtDateName(in io.ByteScanner) bool {
+	if _, ok := in.(*buffer); !ok {
+		return false
+	}
+
+	start := offset(in)
+	defer rewind(in, start)
+
+	word := []byte{}
+	any(in, &word, isalpha)
+
+	return findMonth(word) != -1 || findDayOfWeek(word) != -1
+}
+
+func parseDate(in io.ByteScanner, spec *Timespec) error {
+	c := peek(in)
+
+	if c == 0 {
+		return fmt.Errorf("date: unexpected EOF")
+	}
+
+	buf := []byte{}
+	c = skip(in, isspace)
+	// "next" starts an increment, as in "next week", unless a day of the
+	// week follows, as in "next Tuesday"
+	if c == '+' || c == '-' || (c == 'n' && !lookingAtNextWeekday(in)) || c == 'P' || (c == 'p' && spec.options().wordIncrements) {
+		return nil
+	}
+
+	if spec.options().afterNext && lookingAtAfterNext(in, spec) {
+		return nil
+	}
+
+	// an optional "on" may precede any date: "9am on Tuesday", "on
+	// 2nd Tuesday of March", "on 2015-03-02"
+	if lookingAtWord(in, "on") {
+		in.ReadByte()
+		in.ReadByte()
+		skip(in, isspace)
+	}
+
+	if ok, err := parseOrdinalWeekday(in, spec); ok || err != nil {
+		return err
+	}
+
+	if ok, err := parseISODate(in, spec); ok || err != nil {
+		return err
+	}
+
+	any(in, &buf, isalpha)
+
+	// scanners other than buffers cannot look ahead for "on"
+	if string(buf) == "on" {
+		buf = buf[:0]
+		skip(in, isspace)
+		if ok, err := parseISODate(in, spec); ok || err != nil {
+			return err
+		}
+		any(in, &buf, isalpha)
+	}
+
+	if string(buf) == "day" {
+		return parseDayPhrase(in, spec)
+	}
+
+	if string(buf) == "in" || string(buf) == "year" {
+		return parseBareYear(in, spec)
+	}
+
+	if string(buf) == "today" {
+		spec.setToday()
+		return nil
+	}
+
+	if string(buf) == "tonight" {
+		spec.setToday()
+		spec.isTonight = true
+		return nil
+	}
+
+	if string(buf) == "tomorrow" {
+		spec.isTomorrow = true
+		return nil
+	}
+
+	if string(buf) == "yesterday" {
+		spec.isYesterday = true
+		return nil
+	}
+
+	last := string(buf) == "last"
+	next := string(buf) == "next"
+	this := string(buf) == "this"
+	if last || next || this {
+		buf = buf[:0]
+		skip(in, isspace)
+		any(in, &buf, isalpha)
+	}
+
+	day := findDayOfWeek(buf)
+	if last && day == -1 {
+		return fmt.Errorf("date: expected a day of the week after \"last\", got %q", buf)
+	}
+
+	if period := findPeriod(buf); this && day == -1 && period != -1 {
+		spec.thisPeriod = true
+		spec.thisUnit = Period(period)
+		return nil
+	} else if this && day == -1 {
+		return fmt.Errorf("date: expected a day of the week or unit after \"this\", got %q", buf)
+	}
+
+	if day != -1 {
+		skipAbbreviationDot(in)
+		spec.isWeekday = true
+		spec.lastWeekday = last
+		spec.nextWeekday = next
+		spec.thisWeekday = this
+		spec.weekday = time.Weekday((day + 1) % 7)
+
+		if last || next || this {
+			return nil
+		}
+
+		return parseWeekdayDate(in, spec)
+	}
+
+	month := findMonth(buf)
+	if month == -1 {
+		return fmt.Errorf("date: invalid month name: %q", buf)
+	}
+
+	skipAbbreviationDot(in)
+	spec.month = time.Month(month)
+
+	start := offset(in)
+	if err := parseMonth(in, spec); err != nil {
+		return err
+	}
+
+	if spec.day > daysIn(spec.month, spec.year) && spec.options().strict {
+		// report the error at the day number
+		rewind(in, start)
+		skip(in, isspace)
+		return fmt.Errorf("date: %s has no day %d", spec.month, spec.day)
+	} else if spec.day > daysIn(spec.month, spec.year) {
+		warn(in, spec, "%s has no day %d, rolling over into the next month", spec.month, spec.day)
+	}
+
+	return nil
+}
+
+// bareYear matches a four-digit year following a day without a comma,
+// as in "Mar 02 2015", but not the start of a time such as "0930am".
+var bareYear = regexp.MustCompile(`^\d{4}($|[^\w:])`)
+
+// lookingAtYear reports whether in is positioned at a year matching
+// bareYear, without consuming any input.
+func lookingAtYear(in io.ByteScanner) bool {
+	buf, ok := in.(*buffer)
+
+	return ok && bareYear.MatchString(buf.src[buf.pos:])
+}
+
+// isoDate matches an ISO 8601 calendar date such as "2015-03-02".
+var isoDate = regexp.MustCompile(`^\d{4}-\d\d-\d\d`)
+
+// lookingAtISODate reports whether in is positioned at an ISO 8601
+// calendar date, without consuming any input.
+func lookingAtISODate(in io.ByteScanner) bool {
+	buf, ok := in.(*buffer)
+	if !ok {
+		return false
+	}
+
+	rest := buf.src[buf.pos:]
+	if !isoDate.MatchString(rest) {
+		return false
+	}
+
+	// "2015-03-021" is not a date
+	return len(rest) == 10 || !isdigit(rest[10])
+}
+
+// parseISODate parses an ISO 8601 calendar date such as "2015-03-02"
+// and reports whether it found one.  Months outside of 1-12 and days
+// beyond the end of the month are rejected, with the error pointing at
+// the offending field.  Any other input is left alone.
+func parseISODate(in io.ByteScanner, spec *Timespec) (bool, error) {
+	if !lookingAtISODate(in) {
+		return false, nil
+	}
+
+	buf := in.(*buffer)
+	start := buf.pos
+	date := buf.src[start : start+10]
+
+	year, _ := strconv.Atoi(date[0:4])
+	month, _ := strconv.Atoi(date[5:7])
+	day, _ := strconv.Atoi(date[8:10])
+
+	if month < 1 || month > 12 {
+		buf.pos = start + 5
+		return true, fmt.Errorf("date: invalid month: %d", month)
+	}
+
+	if day < 1 || day > daysIn(time.Month(month), year) {
+		buf.pos = start + 8
+		return true, fmt.Errorf("date: %s %d has no day %d", time.Month(month), year, day)
+	}
+
+	spec.year, spec.month, spec.day = year, time.Month(month), day
+	buf.pos = start + 10
+
+	return true, nil
+}
+
+// parseWeekdayDate parses the date that may follow a day of the week,
+// optionally separated by a comma, as in "Monday, March 2, 2015".  The
+// date replaces the day of the week, which is only checked against it
+// when parsing with VerifyWeekday.  Any other input is left alone.
+func parseWeekdayDate(in io.ByteScanner, spec *Timespec) error {
+	if _, ok := in.(*buffer); !ok {
+		return nil
+	}
+
+	start := offset(in)
+	if skip(in, isspace) == ',' {
+		in.ReadByte()
+	}
+
+	skip(in, isspace)
+	dateStart := offset(in)
+
+	word := []byte{}
+	any(in, &word, isalpha)
+	skipAbbreviationDot(in)
+	if len(word) == 0 || findMonth(word) == -1 || !isdigit(skip(in, isspace)) {
+		rewind(in, start)
+		return nil
+	}
+
+	weekday := spec.weekday
+	spec.isWeekday, spec.weekday = false, time.Sunday
+
+	rewind(in, dateStart)
+	if err := parseDate(in, spec); err != nil {
+		return err
+	}
+
+	if !spec.options().verifyWeekday || spec.year == 0 {
+		return nil
+	}
+
+	if actual := time.Date(spec.year, spec.month, spec.day, 0, 0, 0, 0, time.UTC).Weekday(); actual != weekday {
+		return fmt.Errorf("date: %s %d, %d is a %s, not a %s", spec.month, spec.day, spec.year, actual, weekday)
+	}
+
+	return nil
+}
+
+// skipAbbreviationDot consumes a '.' directly following the name of a
+// month or a day of the week, as in "Mon." or "Feb. 2".
+func skipAbbreviationDot(in io.ByteScanner) {
+	if c, err := in.ReadByte(); err == nil && c != '.' {
+		in.UnreadByte()
+	}
+}
+
+// parseDayPhrase parses the remainder of "day after tomorrow" or "day
+// before yesterday", whose "day" has already been read.
+func parseDayPhrase(in io.ByteScanner, spec *Timespec) error {
+	words := [2][]byte{}
+	for i := range words {
+		skip(in, isspace)
+		any(in, &words[i], isalpha)
+	}
+
+	switch phrase := string(words[0]) + " " + string(words[1]); phrase {
+	case "after tomorrow":
+		spec.dayOffset = 2
+	case "before yesterday":
+		spec.dayOffset = -2
+	default:
+		return fmt.Errorf("date: expected %q or %q, got %q",
+			"day after tomorrow", "day before yesterday", "day "+phrase)
+	}
+
+	return nil
+}
+
+// parseBareYear parses the year following "in" or "year", which refers
+// to January 1 of that year.
+func parseBareYear(in io.ByteScanner, spec *Timespec) error {
+	buf := []byte{}
+	skip(in, isspace)
+	if _, ok := expectN(4, in, &buf, isdigit); !ok || isdigit(peek(in)) {
+		return fmt.Errorf("year: expected a four-digit year")
+	}
+
+	year, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return fmt.Errorf("year: invalid year: %s", buf)
+	}
+
+	spec.year, spec.month, spec.day = year, time.January, 1
+
+	return nil
+}
+
+func parseMonth(in io.ByteScanner, spec *Timespec) error {
+	buf := []byte{}
+	skip(in, isspace)
+	_, ok := expectN(2, in, &buf, isdigit)
+	if !ok && len(buf) != 1 {
+		return fmt.Errorf("month: expected 1 or 2 digits, got: %q", buf)
+	}
+
+	// the number following the month is the day, so it cannot run on
+	// into a time: "March 123" is not March 12 at 3am
+	if c, err := in.ReadByte(); err == nil {
+		in.UnreadByte()
+		if ok && isdigit(c) {
+			return fmt.Errorf("month: invalid day number: %s%c", buf, c)
+		}
+	}
+
+	day, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return fmt.Errorf("month: invalid day number: %s", buf)
+	}
+
+	spec.day = day
+
+	skip(in, isspace)
+	if lookingAtYear(in) {
+		return parseYear(in, spec)
+	}
+
+	if c, err := in.ReadByte(); err == nil && c == ',' {
+		return parseYear(in, spec)
+	} else if err == nil {
+		in.UnreadByte()
+	}
+
+	return nil
+}
+
+func parseYear(in io.ByteScanner, spec *Timespec) error {
+	buf := []byte{}
+
+	skip(in, isspace)
+	expectN(4, in, &buf, isdigit)
+
+	year, err := strconv.ParseInt(string(buf), 10, 0)
+	if err != nil {
+		return fmt.Errorf("year: invalid year format: %q", buf)
+	}
+
+	spec.year = int(year)
+
+	if options := spec.options(); len(buf) == 2 && options.twoDigitYears {
+		if spec.year < options.yearPivot {
+			spec.year += 2000
+		} else {
+			spec.year += 1900
+		}
+	}
+
+	return nil
+}
+
+// findMonth returns the number of the month named by buf, ignoring
+// case, or -1 if buf does not name one.
+func findMonth(buf []byte) int {
+	if month, ok := monthsByName[strings.ToLower(string(buf))]; ok {
+		return month
+	}
+
+	return -1
+}
+
+// findDayOfWeek returns the index of the day of the week named by buf,
+// counting from Monday and ignoring case, or -1 if buf does not name
+// one.
+func findDayOfWeek(buf []byte) int {
+	if day, ok := daysByName[strings.ToLower(string(buf))]; ok {
+		return day
+	}
+
+	return -1
+}
+
+func parseTime(in io.ByteScanner, spec *Timespec) error {
+	c := peek(in)
+
+	if isdigit(c) {
+		return parseClock(in, spec)
+	} else if c == 'n' {
+		return parseNoon(in, spec)
+	} else if c == 'm' {
+		return parseMidnight(in, spec)
+	} else if lookingAtWord(in, "eod") || lookingAtWord(in, "sod") {
+		return parseBusinessAbbreviation(in, spec)
+	} else if c == 'b' || c == 'e' || c == 's' {
+		return parseEdge(in, spec)
+	}
+
+	return fmt.Errorf("time: unexpected character %c", c)
+}
+
+// parseEdge parses "beginning of" or "end of" followed by "day", "month"
+// or "year", as well as "start of business day" and "end of business
+// day".  With WithFiscalYearStart, "fiscal year" may follow any of
+// these.
+func parseEdge(in io.ByteScanner, spec *Timespec) error {
+	word, edge := "end", edgeEnd
+	switch peek(in) {
+	case 'b':
+		word, edge = "beginning", edgeBeginning
+	case 's':
+		word, edge = "start", edgeBeginning
+	}
+
+	for _, expected := range []string{word, "of"} {
+		skip(in, isspace)
+		if s, ok := expectBytes(in, []byte(expected)); !ok {
+			return fmt.Errorf("edge: expected %q, got %q", expected, s)
+		}
+	}
+
+	buf := []byte{}
+	skip(in, isspace)
+	any(in, &buf, isalpha)
+
+	if string(buf) == "business" {
+		skip(in, isspace)
+		if s, ok := expectBytes(in, []byte("day")); !ok {
+			return fmt.Errorf("edge: expected %q, got %q", "day", s)
+		}
+
+		setBusinessTime(spec, edge)
+		return nil
+	} else if string(buf) == "fiscal" {
+		return parseFiscalYear(in, spec, edge)
+	} else if word == "start" {
+		return fmt.Errorf("edge: expected %q, got %q", "business", buf)
+	}
+
+	switch string(buf) {
+	case "day":
+		spec.edgeUnit = Days
+	case "month":
+		spec.edgeUnit = Months
+	case "year":
+		spec.edgeUnit = Years
+	default:
+		return fmt.Errorf("edge: expected \"day\", \"month\" or \"year\", got %q", buf)
+	}
+
+	spec.edge = edge
+
+	return nil
+}
+
+// parseFiscalYear parses the "year" following "fiscal" in a "beginning
+// of" or "end of" phrase.
+func parseFiscalYear(in io.ByteScanner, spec *Timespec, edge edgeType) error {
+	if spec.options().fiscalMonth == 0 {
+		return fmt.Errorf("edge: fiscal years require a fiscal year start")
+	}
+
+	skip(in, isspace)
+	if s, ok := expectBytes(in, []byte("year")); !ok {
+		return fmt.Errorf("edge: expected %q, got %q", "year", s)
+	}
+
+	spec.edge, spec.edgeUnit, spec.fiscal = edge, Years, true
+
+	return nil
+}
+
+// parseClock parses a time given as digits.  It scans the hours,
+// minutes and seconds in a single forward pass, keeping the byte read
+// last in c, and only backs up over that byte once it is done.
+func parseClock(in io.ByteScanner, spec *Timespec) error {
+	c, _ := in.ReadByte()
+	hours := int(c - '0')
+
+	c, readErr := in.ReadByte()
+	if isdigit(c) {
+		hours = hours*10 + int(c-'0')
+		if hours > 23 {
+			return fmt.Errorf("clock: invalid hours: %d", hours)
+		}
+		c, readErr = in.ReadByte()
+	} else {
+		// a single digit may be separated from the minutes by spaces
+		for isspace(c) {
+			c, readErr = in.ReadByte()
+		}
+	}
+
+	spec.hours = hours
+
+	// hours and minutes written as a four-digit number are always on
+	// the 24-hour clock
+	compact := isdigit(c)
+
+	if compact || isMinuteSeparator(spec, c) {
+		if !compact {
+			c, _ = in.ReadByte()
+		}
+
+		if err := scanClockField(in, c, &spec.minutes, "minute"); err != nil {
+			return err
+		}
+
+		c, readErr = in.ReadByte()
+
+		// a six-digit time carries seconds as well, as in "143005", and
+		// so does one with another colon, as in "14:30:05"
+		if (compact && isdigit(c)) || (!compact && c == ':') {
+			if c == ':' {
+				c, _ = in.ReadByte()
+			}
+
+			if err := scanClockField(in, c, &spec.seconds, "second"); err != nil {
+				return err
+			}
+
+			c, readErr = in.ReadByte()
+		}
+	}
+
+	if readErr == nil {
+		in.UnreadByte()
+	}
+
+	c = skip(in, isspace)
+
+	if (c == 'n' || c == 'm') && spec.options().tolerantKeywords &&
+		spec.hours == 12 && spec.minutes == 0 {
+		return parseTime(in, spec)
+	}
+
+	var err error
+	meridiem := false
+	if c != 0 && (strings.IndexByte("aApP", c) != -1 || spec.options().meridiemNames != nil) {
+		if meridiem, err = parseAmPm(in, spec); err != nil {
+			return err
+		}
+	}
+
+	if !meridiem && !compact && spec.hours >= 1 && spec.hours <= 12 {
+		switch spec.options().defaultMeridiem {
+		case MeridiemAM:
+			spec.hours = applyMeridiem(spec.hours, false)
+		case MeridiemPM:
+			spec.hours = applyMeridiem(spec.hours, true)
+		}
+	}
+
+	return parseOptionalTimeZone(in, spec)
+}
+
+// scanTwoDigits reads the digit following first, which has been read
+// already, and returns the number both form.  field names the field
+// being read in errors.  A non-digit is left unread.
+func scanTwoDigits(in io.ByteScanner, first byte, field string) (int, error) {
+	if !isdigit(first) {
+		in.UnreadByte()
+		return 0, fmt.Errorf("%s: expected digit, got '%c'", field, first)
+	}
+
+	second, _ := in.ReadByte()
+	if !isdigit(second) {
+		in.UnreadByte()
+		return 0, fmt.Errorf("%s: expected digit, got '%c'", field, second)
+	}
+
+	return int(first-'0')*10 + int(second-'0'), nil
+}
+
+// scanClockField reads the minutes or seconds of a time, whose first
+// digit has been read already, into field.  name names the field in
+// errors, which for values of 60 or more refer to the start of the
+// digits.
+func scanClockField(in io.ByteScanner, first byte, field *int, name string) error {
+	value, err := scanTwoDigits(in, first, name)
+	if err != nil {
+		return err
+	}
+
+	if value >= 60 {
+		in.UnreadByte()
+		in.UnreadByte()
+		return fmt.Errorf("%s: invalid %ss: %d", name, name, value)
+	}
+
+	*field = value
+
+	return nil
+}
+
+// isMinuteSeparator reports whether c separates the hours from the
+// minutes of a time, which is ':' and, under DotSeparator, '.'.
+func isMinuteSeparator(spec *Timespec, c byte) bool {
+	return c == ':' || c == '.' && spec.options().dotSeparator
+}
+
+func parseTimeZone(in io.ByteScanner, spec *Timespec) error {
+	c := skip(in, isspace)
+
+	if c == '+' || c == '-' {
+		parseOffset(in, spec)
+		return nil
+	}
+
+	if table := spec.options().timezones; table != nil && isalpha(c) {
+		buf := []byte{}
+		any(in, &buf, isalpha)
+
+		if loc := lookupTimeZone(table, string(buf)); loc != nil {
+			spec.location = loc
+			spec.zone = string(buf)
+			return checkTimeZone(spec, string(buf))
+		}
+
+		// not a zone from the table; the parser's buffer allows
+		// backing up over the whole word
+		for range buf {
+			in.UnreadByte()
 		}
 	}
 
-	return "", true
-}
-
-func any(in io.ByteScanner, out *[]byte, class charclass) {
-	c, err := in.ReadByte()
-	if err != nil {
-		return
+	if isalpha(c) {
+		if ok, err := parseZoneName(in, spec); ok || err != nil {
+			return err
+		}
 	}
 
-	for class(c) && err == nil {
-		*out = append(*out, c)
-		c, err = in.ReadByte()
+	// apart from the table, only UTC and GMT (case insensitive) are
+	// valid timezones
+	if c != 'u' && c != 'U' && c != 'g' && c != 'G' {
+		return nil
 	}
 
-	in.UnreadByte()
-}
+	start := offset(in)
+	buf := []byte{}
+	dotted := false
 
-func expectN(n int, in io.ByteScanner, out *[]byte, class charclass) (byte, bool) {
-	var c byte
+	// the letters may be separated by dots, as in "U.T.C."
+	for len(buf) < 3 {
+		c, err := in.ReadByte()
+		if err != nil || !nospace(c) {
+			in.UnreadByte()
+			break
+		}
 
-	for i := 0; i < n; i++ {
-		c, ok := expect(in, out, class)
-		if !ok {
-			return c, false
+		if c == '.' {
+			dotted = true
+		} else {
+			buf = append(buf, c)
 		}
 	}
 
-	return c, true
-}
-
-func parseTimespec(in io.ByteScanner, spec *Timespec) error {
-	c := peek(in)
-	if c == 0 {
-		return fmt.Errorf("timespec: unexpected EOF")
+	if dotted && peek(in) == '.' {
+		in.ReadByte()
 	}
 
-	if c == 'n' {
-		actual, ok := expectBytes(in, []byte("now"))
-		if !ok {
-			return fmt.Errorf("timespec: expected %q, got %q", "now", actual)
-		}
+	timezone := strings.ToUpper(string(buf))
 
-		spec.isNow = true
-		return parseincrement(in, spec)
+	// a longer word such as "garbage" or "until" is no timezone, and is
+	// left whole for the following productions
+	if timezone != "UTC" && timezone != "GMT" && !dotted && isalpha(peek(in)) {
+		rewind(in, start)
+		return nil
 	}
 
-	err := parseTime(in, spec)
-	if err != nil {
+	if timezone != "UTC" && timezone != "GMT" {
+		err := fmt.Errorf("timezone: invalid timezone: %q", buf)
+		backtrack(in, spec, start)
 		return err
 	}
 
-	err = parseDate(in, spec)
-	if err != nil {
-		spec.year = 0
-		spec.month = 0
-		spec.day = 0
-	}
+	spec.zone = timezone
 
-	err = parseincrement(in, spec)
-	if err != nil {
-		spec.increments = 0
+	// not peek, which would back up over the zone at the end of input
+	if c, err := in.ReadByte(); err == nil {
+		in.UnreadByte()
+		if !dotted && (c == '+' || c == '-') {
+			parseZoneOffset(in, spec, timezone)
+		}
 	}
 
-	return nil
+	return checkTimeZone(spec, timezone)
 }
 
-func parseincrement(in io.ByteScanner, spec *Timespec) error {
-	skip(in, isspace)
-	c, _ := in.ReadByte()
-
-	if c == 0 {
-		return nil
+// parseZoneName parses an IANA timezone name such as "America/New_York"
+// or "Etc/GMT+2", and reports whether it found one.  Only capitalized
+// names containing a slash are considered, so that words like
+// "tomorrow" or paths are left alone.  Unknown names are reported at the
+// start of the name.
+func parseZoneName(in io.ByteScanner, spec *Timespec) (bool, error) {
+	if _, ok := in.(*buffer); !ok {
+		return false, nil
 	}
 
-	if c == 'n' {
-		in.UnreadByte()
-		actual, ok := expectBytes(in, []byte("next"))
-		if !ok {
-			return fmt.Errorf("increment: expected \"next\", got %q", actual)
-		}
+	if c := peek(in); c < 'A' || c > 'Z' {
+		return false, nil
+	}
 
-		spec.increments = 1
-	} else if c == '+' {
-		buf := []byte{}
-		skip(in, isspace)
-		any(in, &buf, isdigit)
-		count, err := strconv.ParseInt(string(buf), 10, 0)
-		if err != nil {
-			return fmt.Errorf("increment: %s", err)
-		}
+	start := offset(in)
+	name := []byte{}
+	any(in, &name, isZoneNameByte)
 
-		spec.increments = int(count)
-	} else {
-		return fmt.Errorf("increment: expected '+', got '%c'", c)
+	if strings.IndexByte(string(name), '/') == -1 {
+		rewind(in, start)
+		return false, nil
 	}
 
-	buf := []byte{}
-	skip(in, isspace)
-	any(in, &buf, nospace)
-
-	period := findPeriod(buf)
-	if period == -1 {
-		return fmt.Errorf("period: invalid period: %q", buf)
+	loc, err := time.LoadLocation(string(name))
+	if err != nil {
+		rewind(in, start)
+		return true, &unknownTimeZoneError{zone: string(name)}
 	}
 
-	spec.unit = incrementType(period)
+	spec.location, spec.zone, spec.namedZone = loc, string(name), true
 
-	return nil
+	return true, checkTimeZone(spec, string(name))
 }
 
-func findPeriod(buf []byte) int {
-	return findInRegexpList(periodNames, buf)
+// isZoneNameByte reports whether c may be part of an IANA timezone name.
+func isZoneNameByte(c byte) bool {
+	return isalpha(c) || isdigit(c) || strings.IndexByte("/_+-", c) != -1
 }
 
-func parseDate(in io.ByteScanner, spec *Timespec) error {
-	c := peek(in)
-
-	if c == 0 {
-		return fmt.Errorf("date: unexpected EOF")
-	}
+// parseOffset parses a numeric UTC offset such as "+0200" or "-05:00"
+// and records it as the location of spec.  Anything else, such as the
+// "+" of an increment, is left alone.
+func parseOffset(in io.ByteScanner, spec *Timespec) {
+	start := offset(in)
+	sign, _ := in.ReadByte()
 
 	buf := []byte{}
-	c = skip(in, isspace)
-	if c == '+' || c == 'n' {
-		return nil
+	if _, ok := expectN(2, in, &buf, isdigit); !ok {
+		rewind(in, start)
+		return
 	}
 
-	any(in, &buf, nospace)
+	colon := true
+	if c, _ := in.ReadByte(); c != ':' {
+		in.UnreadByte()
+		colon = false
+	}
 
-	if string(buf) == "today" {
-		spec.setToday()
-		return nil
+	if _, ok := expectN(2, in, &buf, isdigit); !ok {
+		rewind(in, start)
+		return
 	}
 
-	if string(buf) == "tomorrow" {
-		spec.isTomorrow = true
-		return nil
+	if c := peek(in); isdigit(c) || isalpha(c) {
+		rewind(in, start)
+		return
 	}
 
-	day := findDayOfWeek(buf)
-	if day != -1 {
-		spec.day = day
-		return nil
+	// "+0200 minutes" is an increment, but an increment's count never
+	// contains a colon, so "+02:00 day after tomorrow" keeps its offset
+	// even though "day" names a unit.
+	if !colon {
+		end := offset(in)
+		word := []byte{}
+		skip(in, isspace)
+		any(in, &word, isalpha)
+		if findPeriod(word) != -1 {
+			rewind(in, start)
+			return
+		}
+		rewind(in, end)
 	}
 
-	month := findMonth(buf)
-	if month == -1 {
-		return fmt.Errorf("date: invalid month name: %q", buf)
+	hours, _ := strconv.Atoi(string(buf[:2]))
+	minutes, _ := strconv.Atoi(string(buf[2:]))
+	if hours > 23 || minutes > 59 {
+		rewind(in, start)
+		return
 	}
 
-	spec.month = time.Month(month)
+	seconds := hours*60*60 + minutes*60
+	if sign == '-' {
+		seconds = -seconds
+	}
 
-	return parseMonth(in, spec)
+	spec.location = time.FixedZone("", seconds)
+	spec.zone = fmt.Sprintf("%c%02d:%02d", sign, hours, minutes)
 }
 
-func parseMonth(in io.ByteScanner, spec *Timespec) error {
+// parseZoneOffset parses the offset following "UTC" or "GMT" in zone,
+// as in "UTC+2", "GMT-5" or "UTC+05:30".  Unlike on its own, the hours
+// of such an offset may be a single digit.  Anything else, such as the
+// "+1" of "UTC+1 day", is left alone.
+func parseZoneOffset(in io.ByteScanner, spec *Timespec, zone string) {
+	start := offset(in)
+	sign, _ := in.ReadByte()
+
 	buf := []byte{}
-	skip(in, isspace)
-	c, ok := expectN(2, in, &buf, isdigit)
-	if !ok {
-		return fmt.Errorf("month: expected 2 digits, got: %q", buf)
+	any(in, &buf, isdigit)
+
+	var hours, minutes int
+	colon := false
+	switch len(buf) {
+	case 1, 2:
+		hours, _ = strconv.Atoi(string(buf))
+		if peek(in) == ':' {
+			colon = true
+			in.ReadByte()
+			digits := []byte{}
+			if _, ok := expectN(2, in, &digits, isdigit); !ok {
+				rewind(in, start)
+				return
+			}
+			minutes, _ = strconv.Atoi(string(digits))
+		}
+	case 4:
+		hours, _ = strconv.Atoi(string(buf[:2]))
+		minutes, _ = strconv.Atoi(string(buf[2:]))
+	default:
+		rewind(in, start)
+		return
 	}
 
-	day, err := strconv.Atoi(string(buf))
-	if err != nil {
-		return fmt.Errorf("month: invalid day number: %s", buf)
+	if c := peek(in); isdigit(c) || isalpha(c) || hours > 23 || minutes > 59 {
+		rewind(in, start)
+		return
 	}
 
-	spec.day = day
+	if !colon {
+		end := offset(in)
+		word := []byte{}
+		skip(in, isspace)
+		any(in, &word, isalpha)
+		if findPeriod(word) != -1 {
+			rewind(in, start)
+			return
+		}
+		rewind(in, end)
+	}
 
-	skip(in, isspace)
-	c, _ = in.ReadByte()
-	if c == ',' {
-		return parseYear(in, spec)
-	} else {
-		in.UnreadByte()
+	seconds := hours*60*60 + minutes*60
+	if sign == '-' {
+		seconds = -seconds
 	}
 
-	return nil
+	spec.location = time.FixedZone("", seconds)
+	spec.zone = fmt.Sprintf("%s%c%02d:%02d", zone, sign, hours, minutes)
 }
 
-func parseYear(in io.ByteScanner, spec *Timespec) error {
-	buf := []byte{}
-
-	skip(in, isspace)
-	expectN(4, in, &buf, isdigit)
+// disallowedTimeZoneError is returned for timezones excluded by
+// WithAllowedTimezones.  Unlike other problems with a timezone it makes
+// parsing fail.
+type disallowedTimeZoneError struct {
+	zone    string
+	allowed []string
+}
 
-	year, err := strconv.ParseInt(string(buf), 10, 0)
-	if err != nil {
-		return fmt.Errorf("year: invalid year format: %q", buf)
-	}
+func (err *disallowedTimeZoneError) Error() string {
+	return fmt.Sprintf("timezone: %q is not allowed, expected one of: %s",
+		err.zone, strings.Join(err.allowed, ", "))
+}
 
-	spec.year = int(year)
+// unknownTimeZoneError is returned for IANA timezone names that cannot be
+// loaded.  Unlike other invalid timezones, these are never skipped.
+type unknownTimeZoneError struct {
+	zone string
+}
 
-	return nil
+func (err *unknownTimeZoneError) Error() string {
+	return fmt.Sprintf("timezone: unknown timezone: %q", err.zone)
 }
 
-func findInRegexpList(list []*regexp.Regexp, buf []byte) int {
-	for index, re := range list {
-		if re.Match(buf) {
-			return index
+// checkTimeZone returns an error if the options of spec do not allow
+// the timezone zone.
+func checkTimeZone(spec *Timespec, zone string) error {
+	allowed := spec.options().allowedTimezones
+	if allowed == nil {
+		return nil
+	}
+
+	for _, name := range allowed {
+		if strings.EqualFold(name, zone) {
+			return nil
 		}
 	}
 
-	return -1
+	return &disallowedTimeZoneError{zone: zone, allowed: allowed}
 }
 
-func findMonth(buf []byte) int {
-	index := findInRegexpList(monthNames, buf)
-	if index != -1 {
-		return index + 1
-	} else {
-		return -1
+// parseOptionalTimeZone parses the timezone following a time.  Invalid
+// timezones are skipped, leaving a diagnostic, unless they have been
+// excluded by WithAllowedTimezones, are unknown IANA names or parsing
+// is Strict.
+func parseOptionalTimeZone(in io.ByteScanner, spec *Timespec) error {
+	err := parseTimeZone(in, spec)
+	if _, ok := err.(*disallowedTimeZoneError); ok {
+		return err
+	} else if _, ok := err.(*unknownTimeZoneError); ok {
+		return err
+	} else if err != nil && spec.options().strict {
+		return err
+	} else if err != nil {
+		ignore(in, spec, "timezone", err)
 	}
+
+	return nil
 }
 
-func findDayOfWeek(buf []byte) int {
-	return findInRegexpList(dayNames, buf)
+// lookupTimeZone returns the location table maps name to, ignoring
+// case, or nil.
+func lookupTimeZone(table map[string]*time.Location, name string) *time.Location {
+	for abbreviation, loc := range table {
+		if strings.EqualFold(abbreviation, name) {
+			return loc
+		}
+	}
+
+	return nil
 }
 
-func parseTime(in io.ByteScanner, spec *Timespec) error {
-	c := peek(in)
+// parseAmPm parses a meridiem indicator and adjusts the hours of spec
+// accordingly.  It reports whether it found a meridiem.  Besides "am"
+// and "pm", their dotted forms "a.m." and "p.m." and the names given to
+// WithMeridiemNames are recognized.
+func parseAmPm(in io.ByteScanner, spec *Timespec) (bool, error) {
+	if name, meridiem := findMeridiemName(in, spec); name != "" {
+		for i := 0; i < len(name); i++ {
+			in.ReadByte()
+		}
 
-	if isdigit(c) {
-		return parseClock(in, spec)
-	} else if c == 'n' {
-		return parseNoon(in, spec)
-	} else if c == 'm' {
-		return parseMidnight(in, spec)
+		if spec.hours < 1 || spec.hours > 12 {
+			return false, fmt.Errorf("am_pm: invalid hours for %q: %d", name, spec.hours)
+		}
+
+		spec.hours = applyMeridiem(spec.hours, meridiem == MeridiemPM)
+		return true, nil
 	}
 
-	return fmt.Errorf("time: unexpected character %c", c)
-}
+	if strings.IndexByte("aApP", peek(in)) == -1 {
+		return false, nil
+	}
 
-func parseClock(in io.ByteScanner, spec *Timespec) error {
-	c, _ := in.ReadByte()
+	start := offset(in)
+	c, err := in.ReadByte()
 	buf := []byte{c}
 
-	c, _ = in.ReadByte()
+	c, err = in.ReadByte()
 
-	if c != 0 {
-		if !isdigit(c) {
+	// the dotted forms "a.m." and "p.m."
+	dotted := err == nil && c == '.'
+	if dotted {
+		c, err = in.ReadByte()
+	}
+
+	if !dotted && spec.options().singleLetterMeridiem && (err == io.EOF || isspace(c)) {
+		if err == nil {
 			in.UnreadByte()
-			skip(in, isspace)
-		} else {
-			buf = append(buf, c)
 		}
+		c, err = 'm', nil
 	}
 
-	hours, err := strconv.Atoi(string(buf))
 	if err != nil {
-		return fmt.Errorf("clock: invalid number format: %s", buf)
+		return false, fmt.Errorf("am_pm: %s", err)
 	}
 
-	if hours > 23 {
-		return fmt.Errorf("clock: invalid hours: %d", hours)
+	// any other word, such as the month in "10:00 Apr 12", the "plus"
+	// of an increment or an ISO 8601 duration like "P2W", is left for
+	// the following productions
+	if !dotted && ((isalpha(c) && c != 'm' && c != 'M') || (buf[0] == 'P' && isdigit(c))) {
+		in.UnreadByte()
+		in.UnreadByte()
+		return false, nil
 	}
 
-	spec.hours = hours
-
-	c = peek(in)
-
-	if c == 0 {
-		return fmt.Errorf("clock: unexpected EOF")
+	if c != 'm' && c != 'M' {
+		return false, fmt.Errorf("am_pm: expected 'm', got %c", c)
+	} else {
+		buf = append(buf, c)
 	}
 
-	if isdigit(c) || c == ':' {
-		if err := parseMinute(in, spec); err != nil {
-			return err
-		}
+	if dotted && peek(in) == '.' {
+		in.ReadByte()
 	}
 
-	c = skip(in, isspace)
+	// a longer word such as the timezone in "14:30 America/New_York";
+	// only the parser's buffer allows backing up over all of it
+	if _, ok := in.(*buffer); ok && !dotted && isalpha(peek(in)) {
+		rewind(in, start)
+		return false, nil
+	}
 
-	if c != 0 && strings.IndexByte("aApP", c) != -1 {
-		if err := parseAmPm(in, spec); err != nil {
-			return err
-		}
+	if spec.hours < 1 || spec.hours > 12 {
+		return false, fmt.Errorf("am_pm: invalid hours for %q: %d", buf, spec.hours)
 	}
 
-	parseTimeZone(in, spec)
+	spec.hours = applyMeridiem(spec.hours, strings.ToLower(string(buf)) == "pm")
 
-	return nil
+	return true, nil
 }
 
-func parseMinute(in io.ByteScanner, spec *Timespec) error {
-	c, _ := in.ReadByte()
+// findMeridiemName returns the longest of the names given to
+// WithMeridiemNames that in is positioned at, along with its meridiem,
+// or "" if there is none.
+func findMeridiemName(in io.ByteScanner, spec *Timespec) (string, Meridiem) {
+	found, meridiem := "", MeridiemNone
 
-	if c == 0 {
-		return nil
+	for name, m := range spec.options().meridiemNames {
+		if m != MeridiemNone && len(name) > len(found) && lookingAtWord(in, name) {
+			found, meridiem = name, m
+		}
 	}
 
-	if c != ':' && !isdigit(c) {
-		return fmt.Errorf("minute: expected ':' or digit, got '%c'", c)
-	} else if isdigit(c) {
-		in.UnreadByte()
-	} else if c != ':' {
-		return nil
-	}
+	return found, meridiem
+}
 
-	buf := []byte{}
-	if c, ok := expectN(2, in, &buf, isdigit); !ok {
-		return fmt.Errorf("minute: expected digit, got '%c'", c)
-	}
-	minutes, err := strconv.Atoi(string(buf))
-	if err != nil {
-		return fmt.Errorf("minute: %s", err)
+// applyMeridiem converts a wall clock hour to a 24-hour clock hour:
+// "12 am" is midnight, "12 pm" is noon.
+func applyMeridiem(hours int, pm bool) int {
+	if pm && hours < 12 {
+		return hours + 12
 	}
 
-	if minutes >= 60 {
-		return fmt.Errorf("minute: invalid minutes: %d", minutes)
+	if !pm && hours == 12 {
+		return 0
 	}
 
-	spec.minutes = minutes
-
-	return nil
+	return hours
 }
 
-func parseTimeZone(in io.ByteScanner, spec *Timespec) error {
-	c := skip(in, isspace)
+func parseNoon(in io.ByteScanner, spec *Timespec) error {
+	return parseNoonRest(in, spec, "")
+}
 
-	// only UTC (case insensitive) is a valid timezone
-	if c != 'u' && c != 'U' {
-		return nil
+// parseNoonRest parses the remainder of "noon", of which consumed has
+// already been read.
+func parseNoonRest(in io.ByteScanner, spec *Timespec, consumed string) error {
+	s, ok := expectBytes(in, []byte("noon"[len(consumed):]))
+	if !ok {
+		return fmt.Errorf("noon: expected %q, got %q", "noon", consumed+s)
 	}
 
-	buf := []byte{}
-
-	expectN(3, in, &buf, nospace)
-
-	timezone := strings.ToUpper(string(buf))
+	spec.hours = 12
 
-	if timezone != "UTC" {
-		return fmt.Errorf("timezone: invalid timezone: %q", buf)
+	if err := parseKeywordMinutes(in, spec); err != nil {
+		return err
 	}
 
-	return nil
+	return parseOptionalTimeZone(in, spec)
 }
 
-func parseAmPm(in io.ByteScanner, spec *Timespec) error {
-	c, err := in.ReadByte()
-	buf := []byte{c}
+func parseMidnight(in io.ByteScanner, spec *Timespec) error {
+	s, ok := expectBytes(in, []byte("midni"))
+	if ok {
+		suffix := "ght"
+		if spec.options().tolerantKeywords && peek(in) == 't' {
+			suffix = "te"
+		}
 
-	c, err = in.ReadByte()
-	if err != nil {
-		return fmt.Errorf("am_pm: %s", err)
+		s, ok = expectBytes(in, []byte(suffix))
+		s = "midni" + s
 	}
 
-	if c != 'm' && c != 'M' {
-		return fmt.Errorf("am_pm: expected 'm', got %c", c)
-	} else {
-		buf = append(buf, c)
+	if !ok {
+		return fmt.Errorf("midnight: expected %q, got %q", "midnight", s)
 	}
 
-	if strings.ToLower(string(buf)) == "pm" {
-		spec.hours = (spec.hours % 12) + 12
+	spec.hours = 0
+
+	if err := parseKeywordMinutes(in, spec); err != nil {
+		return err
 	}
 
-	return nil
+	spec.endOfDay = spec.options().midnight == EndOfDay && spec.minutes == 0
+
+	return parseOptionalTimeZone(in, spec)
 }
 
-func parseNoon(in io.ByteScanner, spec *Timespec) error {
-	s, ok := expectBytes(in, []byte("noon"))
-	if !ok {
-		return fmt.Errorf("noon: expected %q, got %q", "noon", s)
+// parseKeywordMinutes reads the ":mm", or ":mm:ss", following "noon" or
+// "midnight" under KeywordMinutes.
+func parseKeywordMinutes(in io.ByteScanner, spec *Timespec) error {
+	if !spec.options().keywordMinutes || peek(in) != ':' {
+		return nil
 	}
 
-	spec.hours = 12
-
-	return nil
-}
+	in.ReadByte()
+	c, _ := in.ReadByte()
+	if err := scanClockField(in, c, &spec.minutes, "minute"); err != nil {
+		return err
+	}
 
-func parseMidnight(in io.ByteScanner, spec *Timespec) error {
-	s, ok := expectBytes(in, []byte("midnight"))
-	if !ok {
-		return fmt.Errorf("midnight: expected %q, got %q", "midnight", s)
+	if peek(in) != ':' {
+		return nil
 	}
 
-	return nil
+	in.ReadByte()
+	c, _ = in.ReadByte()
+
+	return scanClockField(in, c, &spec.seconds, "second")
 }
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -15,23 +17,34 @@ type testTimespec struct {
 	expected *Timespec
 }
 
+var parseTimeTests = []testTimespec{
+	{"1 pm", &Timespec{hours: 13}},
+	{"12 pm", &Timespec{hours: 12}},
+	{"11 pm", &Timespec{hours: 23}},
+	{"11:59 pm", &Timespec{hours: 23, minutes: 59}},
+	{"12:10 UTC", &Timespec{hours: 12, minutes: 10, zone: "UTC"}},
+	{"12:10 utc", &Timespec{hours: 12, minutes: 10, zone: "UTC"}},
+	{"13 UTC", &Timespec{hours: 13, zone: "UTC"}},
+	{"1 am", &Timespec{hours: 1}},
+	{"12 am", &Timespec{hours: 0}},
+	{"12:30 am", &Timespec{hours: 0, minutes: 30}},
+	{"13:15", &Timespec{hours: 13, minutes: 15}},
+	{"14:15:30", &Timespec{hours: 14, minutes: 15, seconds: 30}},
+	{"2:15:30 pm", &Timespec{hours: 14, minutes: 15, seconds: 30}},
+	{"14:15:30 UTC", &Timespec{hours: 14, minutes: 15, seconds: 30, zone: "UTC"}},
+	{"5", &Timespec{hours: 5}},
+	{"9", &Timespec{hours: 9}},
+	{"5 pm", &Timespec{hours: 17}},
+	{"17", &Timespec{hours: 17}},
+	{"12 uTC", &Timespec{hours: 12, zone: "UTC"}},
+	{"1215", &Timespec{hours: 12, minutes: 15}},
+	{"0512 utC", &Timespec{hours: 5, minutes: 12, zone: "UTC"}},
+	{"noon", &Timespec{hours: 12}},
+	{"midnight", &Timespec{}},
+}
+
 func TestParseTime(t *testing.T) {
-	for _, testcase := range []testTimespec{
-		{"1 pm", &Timespec{hours: 13}},
-		{"12 pm", &Timespec{hours: 12}},
-		{"11 pm", &Timespec{hours: 23}},
-		{"11:59 pm", &Timespec{hours: 23, minutes: 59}},
-		{"12:10 UTC", &Timespec{hours: 12, minutes: 10}},
-		{"12:10 utc", &Timespec{hours: 12, minutes: 10}},
-		{"13 UTC", &Timespec{hours: 13}},
-		{"1 am", &Timespec{hours: 1}},
-		{"13:15", &Timespec{hours: 13, minutes: 15}},
-		{"12 uTC", &Timespec{hours: 12}},
-		{"1215", &Timespec{hours: 12, minutes: 15}},
-		{"0512 utC", &Timespec{hours: 5, minutes: 12}},
-		{"noon", &Timespec{hours: 12}},
-		{"midnight", &Timespec{}},
-	} {
+	for _, testcase := range parseTimeTests {
 		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
 		result := Timespec{}
 		err := parseTime(src, &result)
@@ -49,14 +62,23 @@ func TestParseTime(t *testing.T) {
 	}
 }
 
+var parseDateTests = []*testTimespec{
+	{"Feb 02", &Timespec{month: 2, day: 2}},
+	{"Mar 11, 2010", &Timespec{month: 3, day: 11, year: 2010}},
+	{"tomorrow", &Timespec{isTomorrow: true}},
+	{"yesterday", &Timespec{isYesterday: true}},
+	{"today", &Timespec{}},
+	{"December 24 , 2015", &Timespec{month: 12, day: 24, year: 2015}},
+	{"Mar 2, 2015", &Timespec{month: 3, day: 2, year: 2015}},
+	{"Tuesday", &Timespec{isWeekday: true, weekday: time.Tuesday}},
+	{"on Sun", &Timespec{isWeekday: true, weekday: time.Sunday}},
+	{"Mon.", &Timespec{isWeekday: true, weekday: time.Monday}},
+	{"Feb. 2", &Timespec{month: 2, day: 2}},
+	{"Sept. 3, 2015", &Timespec{month: 9, day: 3, year: 2015}},
+}
+
 func TestParseDate(t *testing.T) {
-	for _, testcase := range []*testTimespec{
-		{"Feb 02", &Timespec{month: 2, day: 2}},
-		{"Mar 11, 2010", &Timespec{month: 3, day: 11, year: 2010}},
-		{"tomorrow", &Timespec{isTomorrow: true}},
-		{"today", &Timespec{}},
-		{"December 24 , 2015", &Timespec{month: 12, day: 24, year: 2015}},
-	} {
+	for _, testcase := range parseDateTests {
 		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
 		result := Timespec{}
 		err := parseDate(src, &result)
@@ -75,14 +97,31 @@ func TestParseDate(t *testing.T) {
 	}
 }
 
+var parseIncrementTests = []*testTimespec{
+	{"+1 day", &Timespec{increments: 1, unit: Days, hasIncrement: true}},
+	{"+ 1 day", &Timespec{increments: 1, unit: Days, hasIncrement: true}},
+	{"next week", &Timespec{increments: 1, unit: Weeks, hasIncrement: true}},
+	{"nextday", &Timespec{increments: 1, unit: Days, hasIncrement: true}},
+	{"+ 20 months", &Timespec{increments: 20, unit: Months, hasIncrement: true}},
+	{"next fortnight", &Timespec{increments: 1, unit: Fortnights, hasIncrement: true}},
+	{"next quarter", &Timespec{increments: 1, unit: Quarters, hasIncrement: true}},
+	{"+ 2 quarters", &Timespec{increments: 2, unit: Quarters, hasIncrement: true}},
+	{"+ 1 decade", &Timespec{increments: 1, unit: Decades, hasIncrement: true}},
+	{"next century", &Timespec{increments: 1, unit: Centuries, hasIncrement: true}},
+	{"+ 2 centuries", &Timespec{increments: 2, unit: Centuries, hasIncrement: true}},
+	{"+ 30 seconds", &Timespec{increments: 30, unit: Seconds, hasIncrement: true}},
+	{"+ 0 days", &Timespec{increments: 0, unit: Days, hasIncrement: true}},
+	{"+ 0 minutes", &Timespec{increments: 0, unit: Minutes, hasIncrement: true}},
+	{"- 1 day", &Timespec{increments: -1, unit: Days, hasIncrement: true}},
+	{"- 10 minutes", &Timespec{increments: -10, unit: Minutes, hasIncrement: true}},
+	{"-2 weeks", &Timespec{increments: -2, unit: Weeks, hasIncrement: true}},
+	{"+1-day", &Timespec{increments: 1, unit: Days, hasIncrement: true}},
+	{"+ 2-weeks", &Timespec{increments: 2, unit: Weeks, hasIncrement: true}},
+	{"next-week", &Timespec{increments: 1, unit: Weeks, hasIncrement: true}},
+}
+
 func TestParseincrement(t *testing.T) {
-	for _, testcase := range []*testTimespec{
-		{"+1 day", &Timespec{increments: 1, unit: incrementDays}},
-		{"+ 1 day", &Timespec{increments: 1, unit: incrementDays}},
-		{"next week", &Timespec{increments: 1, unit: incrementWeeks}},
-		{"nextday", &Timespec{increments: 1, unit: incrementDays}},
-		{"+ 20 months", &Timespec{increments: 20, unit: incrementMonths}},
-	} {
+	for _, testcase := range parseIncrementTests {
 		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
 		result := Timespec{}
 		err := parseincrement(src, &result)
@@ -101,37 +140,139 @@ func TestParseincrement(t *testing.T) {
 	}
 }
 
+func TestParseTime_public(t *testing.T) {
+	for _, testcase := range parseTimeTests {
+		result, err := ParseTime(testcase.input)
+		if err != nil {
+			t.Errorf("ParseTime(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, testcase.expected) {
+			t.Errorf("ParseTime(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.input, testcase.expected, result)
+		}
+	}
+}
+
+func TestParseDate_public(t *testing.T) {
+	for _, testcase := range parseDateTests {
+		result, err := ParseDate(testcase.input)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, testcase.expected) {
+			t.Errorf("ParseDate(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.input, testcase.expected, result)
+		}
+	}
+}
+
+func TestParseIncrement_public(t *testing.T) {
+	for _, testcase := range parseIncrementTests {
+		result, err := ParseIncrement(testcase.input)
+		if err != nil {
+			t.Errorf("ParseIncrement(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, testcase.expected) {
+			t.Errorf("ParseIncrement(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.input, testcase.expected, result)
+		}
+	}
+}
+
+func TestParseTime_publicError(t *testing.T) {
+	_, err := ParseTime("25:00")
+	if _, ok := err.(*ParseError); !ok {
+		t.Fatalf("ParseTime(%q): expected a *ParseError, got %#v", "25:00", err)
+	}
+}
+
+var parseTimespecTests = []*testTimespec{
+	{"now + 1 day", &Timespec{
+		increments:   1,
+		hasIncrement: true,
+		unit:         Days,
+		isNow:        true,
+	}},
+	{"now - 2 weeks", &Timespec{
+		increments:   -2,
+		hasIncrement: true,
+		unit:         Weeks,
+		isNow:        true,
+	}},
+	{"now + 1 day + 2 hours", &Timespec{
+		increments:   1,
+		hasIncrement: true,
+		unit:         Days,
+		more:         []Increment{{Count: 2, Unit: Hours}},
+		isNow:        true,
+	}},
+	{"now +1-day", &Timespec{
+		increments:   1,
+		hasIncrement: true,
+		unit:         Days,
+		isNow:        true,
+	}},
+	{"10 am next-week", &Timespec{
+		increments:   1,
+		hasIncrement: true,
+		unit:         Weeks,
+		hours:        10,
+	}},
+	{"now", &Timespec{isNow: true}},
+	{"12:11", &Timespec{
+		hours:   12,
+		minutes: 11,
+	}},
+	{"10 am next week", &Timespec{
+		increments:   1,
+		hasIncrement: true,
+		unit:         Weeks,
+		hours:        10,
+	}},
+	{"14:00 Feb 12, 2015 + 3 week", &Timespec{
+		increments:   3,
+		hasIncrement: true,
+		unit:         Weeks,
+		hours:        14,
+		month:        2,
+		day:          12,
+		year:         2015,
+	}},
+	{"9:00 UTCnextweek", &Timespec{
+		unit:         Weeks,
+		increments:   1,
+		hasIncrement: true,
+		hours:        9,
+		zone:         "UTC",
+	}},
+	{"noonnext week", &Timespec{
+		unit:         Weeks,
+		increments:   1,
+		hasIncrement: true,
+		hours:        12,
+	}},
+	{"midnightnext day", &Timespec{
+		unit:         Days,
+		increments:   1,
+		hasIncrement: true,
+	}},
+	{"noonUTCnextweek", &Timespec{
+		unit:         Weeks,
+		increments:   1,
+		hasIncrement: true,
+		hours:        12,
+		zone:         "UTC",
+	}},
+}
+
 func TestParseTimespec(t *testing.T) {
-	for _, testcase := range []*testTimespec{
-		{"now + 1 day", &Timespec{
-			increments: 1,
-			unit:       incrementDays,
-			isNow:      true,
-		}},
-		{"now", &Timespec{isNow: true}},
-		{"12:11", &Timespec{
-			hours:   12,
-			minutes: 11,
-		}},
-		{"10 am next week", &Timespec{
-			increments: 1,
-			unit:       incrementWeeks,
-			hours:      10,
-		}},
-		{"14:00 Feb 12, 2015 + 3 week", &Timespec{
-			increments: 3,
-			unit:       incrementWeeks,
-			hours:      14,
-			month:      2,
-			day:        12,
-			year:       2015,
-		}},
-		{"9:00 UTCnextweek", &Timespec{
-			unit:       incrementWeeks,
-			increments: 1,
-			hours:      9,
-		}},
-	} {
+	for _, testcase := range parseTimespecTests {
 		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
 		result := Timespec{}
 		err := parseTimespec(src, &result)
@@ -143,7 +284,7 @@ func TestParseTimespec(t *testing.T) {
 		if !reflect.DeepEqual(&result, testcase.expected) {
 			t.Fatalf(`parseTimespec(%q):
 Expected: %#v
-     Got: %#v`)
+     Got: %#v`, testcase.input, testcase.expected, &result)
 		}
 	}
 }
@@ -157,19 +298,19 @@ func TestTimespec_Resolve(t *testing.T) {
 	}{
 		{
 			then: time.Date(2010, 1, 2, 15, 10, 0, 0, time.UTC),
-			at:   Timespec{isNow: true, increments: 1, unit: incrementDays},
+			at:   Timespec{isNow: true, increments: 1, unit: Days},
 		},
 		{
 			then: time.Date(2010, 2, 5, 15, 10, 0, 0, time.UTC),
-			at:   Timespec{isNow: true, increments: 5, unit: incrementWeeks},
+			at:   Timespec{isNow: true, increments: 5, unit: Weeks},
 		},
 		{
 			then: time.Date(2010, 2, 1, 15, 10, 0, 0, time.UTC),
-			at:   Timespec{isNow: true, increments: 1, unit: incrementMonths},
+			at:   Timespec{isNow: true, increments: 1, unit: Months},
 		},
 		{
 			then: time.Date(2014, 1, 1, 15, 10, 0, 0, time.UTC),
-			at:   Timespec{isNow: true, increments: 4, unit: incrementYears},
+			at:   Timespec{isNow: true, increments: 4, unit: Years},
 		},
 		{
 			then: time.Date(2010, 2, 2, 15, 10, 0, 0, time.UTC),
@@ -198,9 +339,65 @@ func ExampleParse() {
 	// Output: 2010-01-08 12:00:00 +0000 UTC
 }
 
+func TestTimespec_Resolve_decadesAndCenturies(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input     string
+		expected  time.Time
+		canonical string
+	}{
+		{"now + 1 decade", time.Date(2025, 3, 2, 9, 30, 0, 0, time.UTC), "now + 1 decade"},
+		{"now + 2 centuries", time.Date(2215, 3, 2, 9, 30, 0, 0, time.UTC), "now + 2 centuries"},
+		{"noon Feb 29, 2016 + 1 century", time.Date(2116, 2, 29, 12, 0, 0, 0, time.UTC), "12:00 Feb 29, 2016 + 1 century"},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.String(); actual != testcase.canonical {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", testcase.input, testcase.canonical, actual)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_Resolve_secondsRollover(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"235945 Feb 28, 2015 + 30 seconds", time.Date(2015, 3, 1, 0, 0, 15, 0, time.UTC)},
+		{"235945 Feb 28, 2016 + 30 seconds", time.Date(2016, 2, 29, 0, 0, 15, 0, time.UTC)},
+		{"235945 Feb 29, 2016 + 30 seconds", time.Date(2016, 3, 1, 0, 0, 15, 0, time.UTC)},
+		{"235945 Dec 31, 2015 + 30 seconds", time.Date(2016, 1, 1, 0, 0, 15, 0, time.UTC)},
+		{"235945 Dec 31, 2015 + 3615 seconds", time.Date(2016, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"now + 1 second", time.Date(2015, 3, 2, 9, 30, 1, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+}
+
 func TestTimespec_Resolve_keepsSeconds(t *testing.T) {
 	now := time.Date(2010, 1, 1, 15, 10, 23, 0, time.UTC)
-	at := &Timespec{isNow: true, increments: 1, unit: incrementDays}
+	at := &Timespec{isNow: true, increments: 1, unit: Days}
 	then := time.Date(2010, 1, 2, 15, 10, 23, 0, time.UTC)
 
 	if atTime := at.Resolve(now); !atTime.Equal(then) {
@@ -208,6 +405,321 @@ func TestTimespec_Resolve_keepsSeconds(t *testing.T) {
 	}
 }
 
+func TestTimespec_Time_noMonotonicReading(t *testing.T) {
+	withMonotonic := NewParser(WithPostResolve(func(time.Time) time.Time {
+		return time.Now()
+	}))
+
+	for _, parser := range []*Parser{defaultParser, withMonotonic} {
+		spec, err := parser.Parse("now")
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", "now", err)
+		}
+
+		// Round(0) strips the monotonic reading, so == only holds for
+		// times without one.
+		if at := spec.Time(); at != at.Round(0) {
+			t.Errorf("Time(): expected no monotonic reading, got %s", at)
+		}
+	}
+}
+
+func TestTimespec_Resolve_noYearZero(t *testing.T) {
+	now := time.Date(2015, 3, 2, 17, 45, 10, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		spec     *Timespec
+		expected time.Time
+	}{
+		{&Timespec{hours: 9}, time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{&Timespec{dateOnly: true}, time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{&Timespec{isTomorrow: true, dateOnly: true}, time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{&Timespec{day: 15, hours: 9}, time.Date(2015, 3, 15, 9, 0, 0, 0, time.UTC)},
+		{&Timespec{year: 2016, hours: 9}, time.Date(2016, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{&Timespec{increments: -1, unit: Days}, time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{&Timespec{year: 1, month: 1, day: 1}, time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if actual := testcase.spec.clone().Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("%#v.Resolve(now): expected %s, got %s", testcase.spec, testcase.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"noon", "midnight + 1 day", "tomorrow", "Friday"} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); actual.Year() != now.Year() {
+			t.Errorf("Parse(%q).Resolve(now): expected a time in %d, got %s", input, now.Year(), actual)
+		}
+	}
+
+	spec, err := Parse("noon Jan 01, 0001")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon Jan 01, 0001", err)
+	}
+
+	if actual := spec.Resolve(now); actual.Year() != 1 {
+		t.Errorf("Parse(%q).Resolve(now): expected a time in year 1, got %s", "noon Jan 01, 0001", actual)
+	}
+}
+
+func TestTimespec_ResolveFunc(t *testing.T) {
+	now := time.Date(2015, 3, 2, 17, 45, 10, 0, time.UTC)
+	calls := 0
+	clock := func() time.Time {
+		calls++
+		return now
+	}
+
+	spec, err := Parse("noon tomorrow + 1 hour")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon tomorrow + 1 hour", err)
+	}
+
+	expected := time.Date(2015, 3, 3, 13, 0, 0, 0, time.UTC)
+	if actual := spec.ResolveFunc(clock); !actual.Equal(expected) {
+		t.Errorf("ResolveFunc(clock): expected %s, got %s", expected, actual)
+	}
+
+	if calls != 1 {
+		t.Errorf("ResolveFunc(clock): expected clock to be called once, got %d calls", calls)
+	}
+}
+
+func TestTimespec_ResolveOnDate(t *testing.T) {
+	date := time.Date(2015, 3, 2, 17, 45, 10, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"9am", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"9am + 30 minutes", time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)},
+		{"11pm + 2 hours", time.Date(2015, 3, 3, 1, 0, 0, 0, time.UTC)},
+		{"9am tomorrow", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"noon Feb 12, 2010", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"Friday", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"now + 1 hour", time.Date(2015, 3, 2, 18, 45, 10, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.ResolveOnDate(date); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveOnDate(%s): expected %s, got %s",
+				testcase.input, date, testcase.expected, actual)
+		}
+	}
+}
+
+func TestParseAndResolve(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	actual, err := ParseAndResolve("noon tomorrow", now)
+	if err != nil {
+		t.Fatalf("ParseAndResolve(%q): %s", "noon tomorrow", err)
+	}
+
+	if expected := time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC); !actual.Equal(expected) {
+		t.Errorf("ParseAndResolve(%q): expected %s, got %s", "noon tomorrow", expected, actual)
+	}
+
+	if _, err := ParseAndResolve("noon blah", now); err != nil {
+		t.Errorf("ParseAndResolve(%q): %s", "noon blah", err)
+	}
+
+	_, err = ParseAndResolve("25:00", now)
+	if perr, ok := err.(*ParseError); !ok || perr.Src != "25:00" {
+		t.Errorf("ParseAndResolve(%q): expected a *ParseError, got %#v", "25:00", err)
+	}
+}
+
+func TestParseAndResolveIn(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	// already Mar 03 in loc
+	now := time.Date(2015, 3, 2, 20, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"9am", time.Date(2015, 3, 3, 9, 0, 0, 0, loc)},
+		{"noon tomorrow", time.Date(2015, 3, 4, 12, 0, 0, 0, loc)},
+		{"9am UTC", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"now + 1 hour", now.Add(time.Hour)},
+	} {
+		actual, err := ParseAndResolveIn(testcase.input, now, loc)
+		if err != nil {
+			t.Errorf("ParseAndResolveIn(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !actual.Equal(testcase.expected) || actual.Location() != loc {
+			t.Errorf("ParseAndResolveIn(%q): expected %s, got %s",
+				testcase.input, testcase.expected.In(loc), actual)
+		}
+	}
+
+	if _, err := ParseAndResolveIn("25:00", now, loc); err == nil {
+		t.Errorf("ParseAndResolveIn(%q): expected an error", "25:00")
+	}
+}
+
+func TestTimespec_ResolveCivil(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// daylight saving time starts at 02:00 on Mar 08, 2015 in New York
+	now := time.Date(2015, 3, 7, 12, 0, 0, 0, newYork)
+	nine := TimeOfDay{Hours: 9}
+
+	for _, testcase := range []struct {
+		input    string
+		at       TimeOfDay
+		expected time.Time
+	}{
+		{"Mar 07, 2015", TimeOfDay{}, time.Date(2015, 3, 7, 5, 0, 0, 0, time.UTC)},
+		{"Mar 08, 2015", TimeOfDay{}, time.Date(2015, 3, 8, 5, 0, 0, 0, time.UTC)},
+		{"Mar 09, 2015", TimeOfDay{}, time.Date(2015, 3, 9, 4, 0, 0, 0, time.UTC)},
+		{"Mar 09, 2015", nine, time.Date(2015, 3, 9, 13, 0, 0, 0, time.UTC)},
+		{"tomorrow", nine, time.Date(2015, 3, 8, 13, 0, 0, 0, time.UTC)},
+		{"Mar 09, 2015 + 1 day", TimeOfDay{}, time.Date(2015, 3, 10, 4, 0, 0, 0, time.UTC)},
+		{"10:30 Mar 09, 2015", nine, time.Date(2015, 3, 9, 14, 30, 0, 0, time.UTC)},
+		{"10:30 UTC Mar 09, 2015", nine, time.Date(2015, 3, 9, 10, 30, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		actual := spec.ResolveCivil(now, newYork, testcase.at)
+		if !actual.Equal(testcase.expected) || actual.Location() != newYork {
+			t.Errorf("Parse(%q).ResolveCivil(now, %s, %s): expected %s, got %s",
+				testcase.input, newYork, testcase.at, testcase.expected.In(newYork), actual)
+		}
+	}
+}
+
+func TestTimespec_ResolveLocal(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// daylight saving time starts at 02:00 on Mar 08, 2015 in New York
+	now := time.Date(2015, 3, 7, 12, 0, 0, 0, newYork)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"9am", time.Date(2015, 3, 7, 14, 0, 0, 0, time.UTC)},
+		{"9am tomorrow", time.Date(2015, 3, 8, 13, 0, 0, 0, time.UTC)},
+		{"noon + 1 day", time.Date(2015, 3, 8, 16, 0, 0, 0, time.UTC)},
+		{"now + 1 hour", time.Date(2015, 3, 7, 18, 0, 0, 0, time.UTC)},
+		{"tomorrow", time.Date(2015, 3, 8, 5, 0, 0, 0, time.UTC)},
+		{"9am UTC tomorrow", time.Date(2015, 3, 8, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		utc, unix := spec.ResolveLocal(now)
+		if !utc.Equal(testcase.expected) || utc.Location() != time.UTC {
+			t.Errorf("Parse(%q).ResolveLocal(now): expected %s, got %s", testcase.input, testcase.expected, utc)
+		}
+
+		if unix != utc.Unix() || unix != testcase.expected.Unix() {
+			t.Errorf("Parse(%q).ResolveLocal(now): expected Unix time %d, got %d",
+				testcase.input, testcase.expected.Unix(), unix)
+		}
+	}
+}
+
+func TestTimespec_ResolveInLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// daylight saving time starts at 02:00 on Mar 08, 2015 in New York,
+	// where now is noon on Mar 07
+	now := time.Date(2015, 3, 7, 17, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"09:00 tomorrow", time.Date(2015, 3, 8, 9, 0, 0, 0, newYork)},
+		{"09:00", time.Date(2015, 3, 7, 9, 0, 0, 0, newYork)},
+		{"09:00 + 1 day", time.Date(2015, 3, 8, 9, 0, 0, 0, newYork)},
+		{"today", time.Date(2015, 3, 7, 0, 0, 0, 0, newYork)},
+		{"09:00 Mar 09, 2015", time.Date(2015, 3, 9, 9, 0, 0, 0, newYork)},
+		{"now + 1 day", time.Date(2015, 3, 8, 12, 0, 0, 0, newYork)},
+		{"09:00 UTC tomorrow", time.Date(2015, 3, 8, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		actual := spec.ResolveInLocation(now, newYork)
+		if !actual.Equal(testcase.expected) || actual.Location() != newYork {
+			t.Errorf("Parse(%q).ResolveInLocation(now, New York): expected %s, got %s",
+				testcase.input, testcase.expected.In(newYork), actual)
+		}
+	}
+
+	for _, input := range []string{"09:00 tomorrow", "now", "Feb 12 + 3 days"} {
+		spec, _ := Parse(input)
+		if expected, actual := spec.Resolve(now), spec.ResolveInLocation(now, time.UTC); !actual.Equal(expected) {
+			t.Errorf("Parse(%q).ResolveInLocation(now, UTC): expected %s, got %s", input, expected, actual)
+		}
+	}
+}
+
+func TestTimespec_ResolveBounded(t *testing.T) {
+	now := time.Date(2015, 3, 2, 9, 30, 0, 0, time.UTC)
+	min := time.Unix(0, 0)
+	max := time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input string
+		valid bool
+	}{
+		{"noon tomorrow", true},
+		{"00:00 Jan 01, 1970", true},
+		{"23:59 Dec 31, 1969", false},
+		{"now + 30 years", false},
+		{"2038-01-19T03:14:07Z", true},
+		{"2038-01-19T03:14:08Z", false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		_, err = spec.ResolveBounded(now, min, max)
+		if testcase.valid && err != nil {
+			t.Errorf("Parse(%q).ResolveBounded(now, min, max): %s", testcase.input, err)
+		} else if !testcase.valid && err == nil {
+			t.Errorf("Parse(%q).ResolveBounded(now, min, max): expected an error", testcase.input)
+		}
+	}
+}
+
 func TestTimespec_Parse_errorMessage(t *testing.T) {
 	_, err := Parse("next week")
 	parseError := err.(*ParseError)
@@ -216,3 +728,2526 @@ func TestTimespec_Parse_errorMessage(t *testing.T) {
 		t.Fatalf("Expected to find %#q in %#q", `got "e"`, parseError.Msg)
 	}
 }
+
+func TestTimespec_Parse_invalidCompactMinutes(t *testing.T) {
+	for _, input := range []string{"1275", "1260"} {
+		_, err := Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		parseError := err.(*ParseError)
+		if !strings.Contains(parseError.Msg, "invalid minutes") {
+			t.Errorf("Parse(%q): expected %q in %q", input, "invalid minutes", parseError.Msg)
+		}
+
+		if parseError.Pos != 2 {
+			t.Errorf("Parse(%q): expected error at position 2, got %d", input, parseError.Pos)
+		}
+	}
+}
+
+func TestTimespec_Resolve_todayWithIncrement(t *testing.T) {
+	now := time.Date(2010, 1, 31, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"10:00 today + 1 day", time.Date(2010, 2, 1, 10, 0, 0, 0, time.UTC)},
+		{"10:00 today", time.Date(2010, 1, 31, 10, 0, 0, 0, time.UTC)},
+		{"18:30 + 2 days", time.Date(2010, 2, 2, 18, 30, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", testcase.input, err)
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParseTime_keywordWithTimeZone(t *testing.T) {
+	for _, testcase := range []testTimespec{
+		{"noon UTC", &Timespec{hours: 12, zone: "UTC"}},
+		{"noon utc", &Timespec{hours: 12, zone: "UTC"}},
+		{"midnight UTC", &Timespec{zone: "UTC"}},
+	} {
+		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
+		result := Timespec{}
+
+		if err := parseTime(src, &result); err != nil {
+			t.Errorf("parseTime(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(&result, testcase.expected) {
+			t.Errorf("parseTime(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.input, testcase.expected, &result)
+		}
+
+		if rest, _ := src.ReadString(0); rest != "" {
+			t.Errorf("parseTime(%q): expected the timezone to be consumed, %q is left", testcase.input, rest)
+		}
+	}
+}
+
+func TestTimespec_Resolve_noonTomorrowWithIncrement(t *testing.T) {
+	now := time.Date(2010, 1, 31, 15, 10, 0, 0, time.UTC)
+	expected := time.Date(2010, 2, 1, 13, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"noon tomorrow + 1 hour",
+		"noon tomorrow +1 hour",
+		"noon tomorrow+1 hour",
+		"12:00 tomorrow + 1 hour",
+	} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", input, expected, resolved)
+		}
+	}
+}
+
+func TestApplyMeridiem(t *testing.T) {
+	for hours := 1; hours <= 12; hours++ {
+		am, pm := hours, hours+12
+		if hours == 12 {
+			am, pm = 0, 12
+		}
+
+		if actual := applyMeridiem(hours, false); actual != am {
+			t.Errorf("applyMeridiem(%d, am): expected %d, got %d", hours, am, actual)
+		}
+
+		if actual := applyMeridiem(hours, true); actual != pm {
+			t.Errorf("applyMeridiem(%d, pm): expected %d, got %d", hours, pm, actual)
+		}
+	}
+}
+
+func TestTimespec_Parse_dateFirst(t *testing.T) {
+	for _, testcase := range []struct {
+		dateFirst, timeFirst string
+	}{
+		{"tomorrow 10am", "10am tomorrow"},
+		{"tomorrow 14:30", "14:30 tomorrow"},
+		{"tomorrow noon + 1 hour", "noon tomorrow + 1 hour"},
+		{"today midnight", "midnight today"},
+		{"today 9:15 pm next week", "9:15 pm today next week"},
+	} {
+		dateFirst, err := Parse(testcase.dateFirst)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.dateFirst, err)
+			continue
+		}
+
+		timeFirst, err := Parse(testcase.timeFirst)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.timeFirst, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(dateFirst, timeFirst) {
+			t.Errorf("Parse(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.dateFirst, timeFirst, dateFirst)
+		}
+	}
+}
+
+func TestTimespec_Resolve_infersYear(t *testing.T) {
+	now := time.Date(2010, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"10:00 Jul 01", time.Date(2010, 7, 1, 10, 0, 0, 0, time.UTC)},
+		{"10:00 Mar 01", time.Date(2011, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"10:00 Jun 01", time.Date(2010, 6, 1, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", testcase.input, err)
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_yearRollover(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		// the date has passed in the current year, so next year is assumed
+		{"Jan 15", time.Date(2010, 12, 10, 9, 0, 0, 0, time.UTC), time.Date(2011, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"noon Nov 30", time.Date(2010, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2011, 11, 30, 12, 0, 0, 0, time.UTC)},
+		// the date is still ahead, so the current year is kept
+		{"Dec 31", time.Date(2010, 1, 3, 9, 0, 0, 0, time.UTC), time.Date(2010, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"23:59 Dec 31", time.Date(2010, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2010, 12, 31, 23, 59, 0, 0, time.UTC)},
+		// within the current month, the current year is kept even if the
+		// date has passed
+		{"Dec 1", time.Date(2010, 12, 10, 9, 0, 0, 0, time.UTC), time.Date(2010, 12, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_ResolveInLocation_yearRollover(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// 02:00 on May 01 in UTC, but still Apr 30 in New York
+	now := time.Date(2015, 4, 30, 22, 0, 0, 0, newYork)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"01:00 May 01", time.Date(2015, 5, 1, 1, 0, 0, 0, newYork)},
+		{"21:00 Apr 30", time.Date(2015, 4, 30, 21, 0, 0, 0, newYork)},
+		{"21:00 Mar 30", time.Date(2016, 3, 30, 21, 0, 0, 0, newYork)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.ResolveInLocation(now, newYork); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveInLocation(now, New York): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_negativeIncrements(t *testing.T) {
+	now := time.Date(2010, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		at   Timespec
+		then time.Time
+	}{
+		{
+			at:   Timespec{year: 2010, month: 3, day: 1, minutes: 10, increments: -30, unit: Minutes},
+			then: time.Date(2010, 2, 28, 23, 40, 0, 0, time.UTC),
+		},
+		{
+			at:   Timespec{minutes: 10, increments: -30, unit: Minutes},
+			then: time.Date(2010, 6, 14, 23, 40, 0, 0, time.UTC),
+		},
+		{
+			at:   Timespec{year: 2010, month: 1, day: 1, increments: -1, unit: Days},
+			then: time.Date(2009, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			at:   Timespec{year: 2010, month: 1, day: 1, hours: 1, increments: -3, unit: Hours},
+			then: time.Date(2009, 12, 31, 22, 0, 0, 0, time.UTC),
+		},
+	} {
+		if resolved := testcase.at.Resolve(now); !resolved.Equal(testcase.then) {
+			t.Errorf("%#v.Resolve(now): expected %s, got %s", testcase.at, testcase.then, resolved)
+		}
+	}
+}
+
+func TestTimespec_Parse_rfc3339(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2015-03-02T14:30:00Z", time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC)},
+		{"2015-03-02T14:30:00+02:00", time.Date(2015, 3, 2, 12, 30, 0, 0, time.UTC)},
+		{"2015-03-02T14:30:00-05:00", time.Date(2015, 3, 2, 19, 30, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Parse_rfc3339Malformed(t *testing.T) {
+	for _, input := range []string{
+		"2015-03-02T14:30Z",
+		"2015-13-02T14:30:00Z",
+		"2015-03-02T25:30:00Z",
+		"2015-03-02T14:30:00",
+	} {
+		_, err := Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		if _, ok := err.(*ParseError); !ok {
+			t.Errorf("Parse(%q): expected a *ParseError, got %#v", input, err)
+		}
+	}
+}
+
+func TestTimespec_Resolve_edges(t *testing.T) {
+	now := time.Date(2015, 2, 12, 15, 10, 30, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"beginning of day", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"end of day", time.Date(2015, 2, 12, 23, 59, 59, 0, time.UTC)},
+		{"beginning of month", time.Date(2015, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of month", time.Date(2015, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{"beginning of year", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"end of year", time.Date(2015, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{"end of day tomorrow", time.Date(2015, 2, 13, 23, 59, 59, 0, time.UTC)},
+		{"end of month Jan 12, 2016", time.Date(2016, 1, 31, 23, 59, 59, 0, time.UTC)},
+		{"end of month Feb 12, 2016", time.Date(2016, 2, 29, 23, 59, 59, 0, time.UTC)},
+		{"beginning of day + 9 hours", time.Date(2015, 2, 12, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Parse_edgeInvalidUnit(t *testing.T) {
+	for _, input := range []string{"end of days", "beginning of", "end off day"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func TestTimespec_Resolve_dateOnly(t *testing.T) {
+	now := time.Date(2015, 1, 10, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Feb 12", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"Feb 12, 2016", time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", time.Date(2015, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow + 2 hours", time.Date(2015, 1, 11, 2, 0, 0, 0, time.UTC)},
+		{"tomorrow next week", time.Date(2015, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow noon", time.Date(2015, 1, 11, 12, 0, 0, 0, time.UTC)},
+		{"yesterday", time.Date(2015, 1, 9, 0, 0, 0, 0, time.UTC)},
+		{"23:30 yesterday", time.Date(2015, 1, 9, 23, 30, 0, 0, time.UTC)},
+		{"yesterday + 2 hours", time.Date(2015, 1, 9, 2, 0, 0, 0, time.UTC)},
+		{"noon yesterday next week", time.Date(2015, 1, 16, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestPeriod_MarshalText(t *testing.T) {
+	for period, expected := range map[Period]string{
+		Minutes:    "minutes",
+		Hours:      "hours",
+		Days:       "days",
+		Weeks:      "weeks",
+		Months:     "months",
+		Years:      "years",
+		Fortnights: "fortnights",
+		Quarters:   "quarters",
+		Decades:    "decades",
+		Centuries:  "centuries",
+	} {
+		if s := period.String(); s != expected {
+			t.Errorf("%d.String(): expected %q, got %q", period, expected, s)
+		}
+
+		text, err := period.MarshalText()
+		if err != nil {
+			t.Errorf("%s.MarshalText(): %s", period, err)
+			continue
+		}
+
+		var result Period
+		if err := result.UnmarshalText(text); err != nil {
+			t.Errorf("UnmarshalText(%q): %s", text, err)
+		} else if result != period {
+			t.Errorf("UnmarshalText(%q): expected %s, got %s", text, period, result)
+		}
+	}
+}
+
+func TestPeriod_UnmarshalText(t *testing.T) {
+	var period Period
+
+	if err := period.UnmarshalText([]byte("week")); err != nil || period != Weeks {
+		t.Errorf("UnmarshalText(%q): expected %s, got %s (%v)", "week", Weeks, period, err)
+	}
+
+	for _, text := range []string{"", "eon", "dayss", "Days"} {
+		if err := period.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q): expected an error", text)
+		}
+	}
+
+	if _, err := Period(42).MarshalText(); err == nil {
+		t.Errorf("MarshalText(): expected an error for an invalid period")
+	}
+}
+
+func TestParseincrement_isoDuration(t *testing.T) {
+	for _, testcase := range []*testTimespec{
+		{"PT90M", &Timespec{increments: 90, unit: Minutes, hasIncrement: true}},
+		{"PT1H30M", &Timespec{increments: 90, unit: Minutes, hasIncrement: true}},
+		{"+ PT2H", &Timespec{increments: 2, unit: Hours, hasIncrement: true}},
+		{"P1D", &Timespec{increments: 1, unit: Days, hasIncrement: true}},
+		{"P1DT12H", &Timespec{increments: 36, unit: Hours, hasIncrement: true}},
+		{"P2W", &Timespec{increments: 2, unit: Weeks, hasIncrement: true}},
+		{"P14D", &Timespec{increments: 2, unit: Weeks, hasIncrement: true}},
+		{"P1Y2M", &Timespec{increments: 14, unit: Months, hasIncrement: true}},
+		{"P2Y", &Timespec{increments: 2, unit: Years, hasIncrement: true}},
+	} {
+		result, err := ParseIncrement(testcase.input)
+		if err != nil {
+			t.Errorf("ParseIncrement(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, testcase.expected) {
+			t.Errorf("ParseIncrement(%q):\n  Expected: %#v\n       Got: %#v\n",
+				testcase.input, testcase.expected, result)
+		}
+	}
+}
+
+func TestParseincrement_isoDurationInvalid(t *testing.T) {
+	for _, input := range []string{"P", "PT", "P1M1D", "PT30S", "P1H", "PT1D"} {
+		if _, err := ParseIncrement(input); err == nil {
+			t.Errorf("ParseIncrement(%q): expected an error", input)
+		}
+	}
+}
+
+func TestTimespec_Resolve_isoDuration(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now PT90M", time.Date(2010, 1, 1, 16, 40, 0, 0, time.UTC)},
+		{"now + PT1H30M", time.Date(2010, 1, 1, 16, 40, 0, 0, time.UTC)},
+		{"noon P1D", time.Date(2010, 1, 2, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_onWeekday(t *testing.T) {
+	// a Wednesday
+	wednesday := time.Date(2010, 1, 6, 15, 10, 0, 0, time.UTC)
+	// a Tuesday, before and after 9am
+	earlyTuesday := time.Date(2010, 1, 5, 8, 0, 0, 0, time.UTC)
+	lateTuesday := time.Date(2010, 1, 5, 10, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"9am on Tuesday", wednesday, time.Date(2010, 1, 12, 9, 0, 0, 0, time.UTC)},
+		{"noon on Friday", wednesday, time.Date(2010, 1, 8, 12, 0, 0, 0, time.UTC)},
+		{"noon Friday", wednesday, time.Date(2010, 1, 8, 12, 0, 0, 0, time.UTC)},
+		{"9am on Tuesday", earlyTuesday, time.Date(2010, 1, 5, 9, 0, 0, 0, time.UTC)},
+		{"9am on Tuesday", lateTuesday, time.Date(2010, 1, 12, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+// TestTimespec_Resolve_weekdayNotDayOfMonth guards against the index
+// returned by findDayOfWeek ending up in the day of the month, which
+// resolved "Tuesday" to the 2nd of the month.
+func TestTimespec_Resolve_weekdayNotDayOfMonth(t *testing.T) {
+	// a Wednesday, late in the month
+	now := time.Date(2015, 3, 25, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Monday", time.Date(2015, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{"Tuesday", time.Date(2015, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"Wednesday", time.Date(2015, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"Thursday", time.Date(2015, 3, 26, 0, 0, 0, 0, time.UTC)},
+		{"Friday", time.Date(2015, 3, 27, 0, 0, 0, 0, time.UTC)},
+		{"Saturday", time.Date(2015, 3, 28, 0, 0, 0, 0, time.UTC)},
+		{"Sunday", time.Date(2015, 3, 29, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.day != 0 || spec.month != 0 {
+			t.Errorf("Parse(%q): expected no day of the month, got month %d, day %d", testcase.input, spec.month, spec.day)
+		}
+
+		resolved := spec.Resolve(now)
+		if resolved.Weekday().String() != testcase.input {
+			t.Errorf("Parse(%q).Resolve(now): expected a %s, got %s", testcase.input, testcase.input, resolved.Weekday())
+		}
+
+		if !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParse_abbreviationDot(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"noon Mon. + 1 day", time.Date(2015, 2, 17, 12, 0, 0, 0, time.UTC)},
+		{"Tue. 9am", time.Date(2015, 2, 17, 9, 0, 0, 0, time.UTC)},
+		{"noon Feb. 2, 2016", time.Date(2016, 2, 2, 12, 0, 0, 0, time.UTC)},
+		{"Mon., Mar. 2, 2015 noon", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_negativeIncrement(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"now - 1 day", time.Date(2015, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(2014, 12, 31, 8, 0, 0, 0, time.UTC)},
+		{"noon - 3 days", time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 27, 12, 0, 0, 0, time.UTC)},
+		{"00:10 - 30 minutes", time.Date(2015, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 28, 23, 40, 0, 0, time.UTC)},
+		{"14:00 -05:00 - 1 day", time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 1, 19, 0, 0, 0, time.UTC)},
+		{"now - 1 hour", time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 2, 7, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", testcase.input, testcase.now, testcase.expected, resolved)
+		}
+
+		if reparsed, err := Parse(spec.String()); err != nil {
+			t.Errorf("Parse(%q): %s", spec.String(), err)
+		} else if resolved := reparsed.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", spec.String(), testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_weekdays(t *testing.T) {
+	// a Tuesday
+	now := time.Date(2015, 2, 10, 11, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"noon Monday", time.Date(2015, 2, 16, 12, 0, 0, 0, time.UTC)},
+		{"noon Mon", time.Date(2015, 2, 16, 12, 0, 0, 0, time.UTC)},
+		{"noon Tuesday", time.Date(2015, 2, 10, 12, 0, 0, 0, time.UTC)},
+		{"noon Tue", time.Date(2015, 2, 10, 12, 0, 0, 0, time.UTC)},
+		{"noon Wednesday", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC)},
+		{"noon Wed", time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC)},
+		{"noon Thursday", time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"noon Thu", time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"noon Friday", time.Date(2015, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{"noon Fri", time.Date(2015, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{"noon Saturday", time.Date(2015, 2, 14, 12, 0, 0, 0, time.UTC)},
+		{"noon Sat", time.Date(2015, 2, 14, 12, 0, 0, 0, time.UTC)},
+		{"noon Sunday", time.Date(2015, 2, 15, 12, 0, 0, 0, time.UTC)},
+		{"noon Sun", time.Date(2015, 2, 15, 12, 0, 0, 0, time.UTC)},
+		// today, unless the time has passed already
+		{"11:00 Tuesday", time.Date(2015, 2, 10, 11, 0, 0, 0, time.UTC)},
+		{"10am Tuesday", time.Date(2015, 2, 17, 10, 0, 0, 0, time.UTC)},
+		{"Tuesday", time.Date(2015, 2, 17, 0, 0, 0, 0, time.UTC)},
+		{"now Tuesday", now},
+		{"10am Friday + 1 week", time.Date(2015, 2, 20, 10, 0, 0, 0, time.UTC)},
+		{"Friday 10am + 1 week", time.Date(2015, 2, 20, 10, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_weekdayInLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("LoadLocation: %s", err)
+	}
+
+	// Friday, 07:00 in New York
+	now := time.Date(2015, 3, 6, 7, 0, 0, 0, newYork)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"09:00 Friday", time.Date(2015, 3, 6, 9, 0, 0, 0, newYork)},
+		{"06:00 Friday", time.Date(2015, 3, 13, 6, 0, 0, 0, newYork)},
+		{"09:00 Saturday", time.Date(2015, 3, 7, 9, 0, 0, 0, newYork)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.ResolveInLocation(now, newYork); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveInLocation(now, New York): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	parser := NewParser(WithFixedOffset(-5 * time.Hour))
+	spec, _ := parser.Parse("09:00 Friday")
+	expected := time.Date(2015, 3, 6, 14, 0, 0, 0, time.UTC)
+	if resolved := spec.Resolve(now); !resolved.Equal(expected) {
+		t.Errorf("Parse(%q).Resolve(now) with WithFixedOffset: expected %s, got %s", "09:00 Friday", expected, resolved)
+	}
+}
+
+func TestTimespec_Resolve_twice(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{
+		"now + 1 hour", "noon tomorrow + 2 days", "Friday", "Feb 12 + 1 week",
+		"midnight tonight", "end of month + 1 month", "day after tomorrow",
+	} {
+		spec, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		str := spec.String()
+		if first, second := spec.Resolve(now), spec.Resolve(now); !first.Equal(second) {
+			t.Errorf("Parse(%q).Resolve(now): got %s, then %s", input, first, second)
+		}
+
+		if actual := spec.String(); actual != str {
+			t.Errorf("Parse(%q).Resolve(now): changed the spec from %q to %q", input, str, actual)
+		}
+	}
+}
+
+func TestTimespec_ResolveNoted(t *testing.T) {
+	now := time.Date(2015, 1, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input      string
+		expected   time.Time
+		normalized bool
+	}{
+		{"Feb 31", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC), true},
+		{"Feb 28", time.Date(2015, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"noon Apr 31, 2016", time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC), true},
+		{"Feb 28 + 1 day", time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"23:30 + 45 minutes", time.Date(2015, 1, 13, 0, 15, 0, 0, time.UTC), false},
+		{"end of month", time.Date(2015, 1, 31, 23, 59, 59, 0, time.UTC), false},
+		{"9am Friday", time.Date(2015, 1, 16, 9, 0, 0, 0, time.UTC), false},
+		{"now", now, false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		resolved, normalized := spec.ResolveNoted(now)
+		if !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).ResolveNoted(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+
+		if normalized != testcase.normalized {
+			t.Errorf("Parse(%q).ResolveNoted(now): expected normalized to be %v, got %v",
+				testcase.input, testcase.normalized, normalized)
+		}
+	}
+}
+
+func TestTimespec_Resolve_differentNow(t *testing.T) {
+	spec, err := Parse("noon tomorrow + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon tomorrow + 1 day", err)
+	}
+
+	for _, testcase := range []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		{time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 14, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2016, 7, 30, 20, 0, 0, 0, time.UTC), time.Date(2016, 8, 1, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 14, 12, 0, 0, 0, time.UTC)},
+	} {
+		if actual := spec.Resolve(testcase.now); !actual.Equal(testcase.expected) {
+			t.Errorf("Resolve(%s): expected %s, got %s", testcase.now, testcase.expected, actual)
+		}
+	}
+}
+
+func TestTimespec_Parse_bareHour(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		hours int
+	}{
+		{"5", 5},
+		{"9", 9},
+		{"5 pm", 17},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours {
+			t.Errorf("Parse(%q): expected hours %d, got %d", testcase.input, testcase.hours, spec.hours)
+		}
+	}
+}
+
+func TestTimespec_Resolve_fortnightAndQuarter(t *testing.T) {
+	now := time.Date(2010, 1, 20, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now next fortnight", time.Date(2010, 2, 3, 15, 10, 0, 0, time.UTC)},
+		{"now next quarter", time.Date(2010, 4, 20, 15, 10, 0, 0, time.UTC)},
+		{"now + 2 fortnights", time.Date(2010, 2, 17, 15, 10, 0, 0, time.UTC)},
+		{"noon next quarter", time.Date(2010, 4, 20, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_ResolveUnix(t *testing.T) {
+	now := time.Date(2010, 1, 1, 15, 10, 0, 0, time.UTC)
+
+	spec, err := Parse("14:00 Feb 12, 2015 + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00 Feb 12, 2015 + 1 day", err)
+	}
+
+	// 2015-02-13 14:00:00 UTC
+	if epoch := spec.ResolveUnix(now); epoch != 1423836000 {
+		t.Errorf("ResolveUnix(now): expected %d, got %d", 1423836000, epoch)
+	}
+}
+
+func TestTimespec_Until(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"now + 90 minutes", 90 * time.Minute},
+		{"now", 0},
+		{"now - 1 hour", -time.Hour},
+		{"07:30", -30 * time.Minute},
+		{"noon tomorrow", 28 * time.Hour},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		// resolving must not change the spec, so that results are stable
+		for i := 0; i < 2; i++ {
+			if until := spec.Until(now); until != testcase.expected {
+				t.Errorf("Parse(%q).Until(now): expected %s, got %s", testcase.input, testcase.expected, until)
+			}
+		}
+
+		if since := spec.Since(now); since != -testcase.expected {
+			t.Errorf("Parse(%q).Since(now): expected %s, got %s", testcase.input, -testcase.expected, since)
+		}
+	}
+}
+
+func TestTimespec_IsAbsolute(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		absolute bool
+	}{
+		{"14:00 Feb 12, 2015", true},
+		{"14:00 Feb 12, 2015 + 1 day", true},
+		{"Feb 12, 2015", true},
+		{"2015-02-12T14:00:00Z", true},
+		{"now + 1 day", false},
+		{"14:00", false},
+		{"14:00 tomorrow", false},
+		{"14:00 Friday", false},
+		{"14:00 Feb 12", false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if absolute := spec.IsAbsolute(); absolute != testcase.absolute {
+			t.Errorf("Parse(%q).IsAbsolute(): expected %v, got %v",
+				testcase.input, testcase.absolute, absolute)
+		}
+	}
+}
+
+func TestTimespec_ResolveAbsolute(t *testing.T) {
+	spec, err := Parse("14:00 Feb 12, 2015 + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00 Feb 12, 2015 + 1 day", err)
+	}
+
+	expected := time.Date(2015, 2, 13, 14, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if resolved, err := spec.ResolveAbsolute(); err != nil || !resolved.Equal(expected) {
+			t.Errorf("ResolveAbsolute(): expected %s, got %s (%v)", expected, resolved, err)
+		}
+	}
+
+	spec, _ = Parse("now + 1 day")
+	if resolved, err := spec.ResolveAbsolute(); err == nil {
+		t.Errorf("Parse(%q).ResolveAbsolute(): expected an error, got %s", "now + 1 day", resolved)
+	}
+}
+
+func TestTimespec_Resolve_todayAndTonight(t *testing.T) {
+	now := time.Date(2015, 2, 12, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"noon today", time.Date(2015, 2, 12, 12, 0, 0, 0, time.UTC)},
+		{"midnight today", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"midnight tonight", time.Date(2015, 2, 13, 0, 0, 0, 0, time.UTC)},
+		{"10pm tonight", time.Date(2015, 2, 12, 22, 0, 0, 0, time.UTC)},
+		{"tonight 10pm", time.Date(2015, 2, 12, 22, 0, 0, 0, time.UTC)},
+		{"tonight", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_lastWeekday(t *testing.T) {
+	// a Monday and a Friday
+	monday := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	friday := time.Date(2015, 3, 6, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"last Friday", monday, time.Date(2015, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"last Friday", friday, time.Date(2015, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"last Thursday", friday, time.Date(2015, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"last Sunday", monday, time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"10am last Fri", monday, time.Date(2015, 2, 27, 10, 0, 0, 0, time.UTC)},
+		{"last Monday 9am", monday, time.Date(2015, 2, 23, 9, 0, 0, 0, time.UTC)},
+		{"noon last Friday + 1 day", friday, time.Date(2015, 2, 28, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_nextWeekday(t *testing.T) {
+	// a Tuesday and a Sunday
+	tuesday := time.Date(2015, 3, 3, 8, 0, 0, 0, time.UTC)
+	sunday := time.Date(2015, 3, 8, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"10am next Tuesday", tuesday, time.Date(2015, 3, 10, 10, 0, 0, 0, time.UTC)},
+		{"10am next Tuesday", sunday, time.Date(2015, 3, 10, 10, 0, 0, 0, time.UTC)},
+		{"next Sunday", tuesday, time.Date(2015, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{"next Sunday", sunday, time.Date(2015, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"next Wednesday", tuesday, time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"next Tue 9am + 1 day", tuesday, time.Date(2015, 3, 11, 9, 0, 0, 0, time.UTC)},
+		// without "next", today counts while its time has not passed
+		{"10am Tuesday", tuesday, time.Date(2015, 3, 3, 10, 0, 0, 0, time.UTC)},
+		{"noon next week", tuesday, time.Date(2015, 3, 10, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+
+	spec, err := ParseDate("next Sunday")
+	if err != nil {
+		t.Fatalf("ParseDate(%q): %s", "next Sunday", err)
+	}
+
+	expected := &Timespec{isWeekday: true, weekday: time.Sunday, nextWeekday: true}
+	if !reflect.DeepEqual(spec, expected) {
+		t.Errorf("ParseDate(%q):\n  Expected: %#v\n       Got: %#v", "next Sunday", expected, spec)
+	}
+}
+
+func TestTimespec_Resolve_this(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2015, 3, 4, 11, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"this Friday", time.Date(2015, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"this Monday", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"this Wednesday", time.Date(2015, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"10am this Sunday", time.Date(2015, 3, 8, 10, 0, 0, 0, time.UTC)},
+		{"this Fri 9am", time.Date(2015, 3, 6, 9, 0, 0, 0, time.UTC)},
+		{"noon this week", time.Date(2015, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{"9am this month", time.Date(2015, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"noon this week + 1 day", time.Date(2015, 3, 5, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	this, err := ParseDate("this Friday")
+	if err != nil {
+		t.Fatalf("ParseDate(%q): %s", "this Friday", err)
+	}
+
+	next, err := ParseDate("next Friday")
+	if err != nil {
+		t.Fatalf("ParseDate(%q): %s", "next Friday", err)
+	}
+
+	if !this.thisWeekday || this.nextWeekday || reflect.DeepEqual(this, next) {
+		t.Errorf("ParseDate(%q): expected a spec distinct from %q, got %#v", "this Friday", "next Friday", this)
+	}
+
+	if _, err := Parse("this thing"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "this thing")
+	}
+
+	spec, err := Parse("noon this week")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "noon this week", err)
+	}
+
+	if spec.HasIncrement() {
+		t.Errorf("Parse(%q).HasIncrement(): expected false", "noon this week")
+	}
+
+	if count, unit, ok := spec.Increment(); ok {
+		t.Errorf("Parse(%q).Increment(): expected no increment, got %d %s", "noon this week", count, unit)
+	}
+
+	if _, _, ok, _ := ExtractIncrement("noon this month"); ok {
+		t.Errorf("ExtractIncrement(%q): expected no increment", "noon this month")
+	}
+
+	if s := spec.String(); s != "12:00 this week" {
+		t.Errorf("Parse(%q).String(): expected %q, got %q", "noon this week", "12:00 this week", s)
+	}
+}
+
+func TestParse_lastWithoutWeekday(t *testing.T) {
+	if _, err := Parse("last March"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "last March")
+	}
+}
+
+func TestParse_wallclockAmPm(t *testing.T) {
+	for _, testcase := range []struct {
+		input   string
+		hours   int
+		minutes int
+	}{
+		{"0930 am", 9, 30},
+		{"0930 pm", 21, 30},
+		{"0930am", 9, 30},
+		{"0930PM", 21, 30},
+		{"9:30 am", 9, 30},
+		{"9:30 pm", 21, 30},
+		{"09:30pm", 21, 30},
+		{"1230 pm", 12, 30},
+		{"1230 am", 0, 30},
+		{"12:30 am", 0, 30},
+		{"0100 pm", 13, 0},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes {
+			t.Errorf("Parse(%q): expected %02d:%02d, got %02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, spec.hours, spec.minutes)
+		}
+	}
+}
+
+func TestParse_wallclockAmPmInvalid(t *testing.T) {
+	for _, input := range []string{"1330 am", "1330 pm", "13:30 pm", "0030 am", "0:30 pm", "2359 am"} {
+		if spec, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error, got %#v", input, spec)
+		}
+	}
+}
+
+func TestParse_dottedAmPm(t *testing.T) {
+	for _, testcase := range []struct {
+		input   string
+		hours   int
+		minutes int
+	}{
+		{"2 a.m.", 2, 0},
+		{"2 p.m.", 14, 0},
+		{"11:30 P.M.", 23, 30},
+		{"11:30 A.M.", 11, 30},
+		{"2a.m.", 2, 0},
+		{"2 a.m", 2, 0},
+		{"12 a.m.", 0, 0},
+		{"12 p.m.", 12, 0},
+		{"12:15 a.m.", 0, 15},
+		{"noon", 12, 0},
+		{"midnight", 0, 0},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes {
+			t.Errorf("Parse(%q): expected %02d:%02d, got %02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, spec.hours, spec.minutes)
+		}
+	}
+
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+	spec, err := NewParser(Strict()).Parse("2 p.m. tomorrow + 1 hour")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "2 p.m. tomorrow + 1 hour", err)
+	}
+
+	if expected, resolved := time.Date(2015, 2, 13, 15, 0, 0, 0, time.UTC), spec.Resolve(now); !resolved.Equal(expected) {
+		t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", "2 p.m. tomorrow + 1 hour", expected, resolved)
+	}
+
+	for _, input := range []string{"13 p.m.", "2 a.x."} {
+		if spec, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error, got %#v", input, spec)
+		}
+	}
+}
+
+func TestParse_monthAfterTime(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		month time.Month
+	}{
+		{"10:00 Apr 12, 2015", time.April},
+		{"10:00 Aug 12, 2015", time.August},
+		{"10am Apr 12", time.April},
+		{"1000 Aug 12", time.August},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.month != testcase.month || spec.day != 12 {
+			t.Errorf("Parse(%q): expected %s 12, got %s %d",
+				testcase.input, testcase.month, spec.month, spec.day)
+		}
+	}
+}
+
+func TestParse_weekdayWithDate(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Monday, March 2, 2015", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"Monday March 02, 2015", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"Mon, Mar 2", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"noon Monday, March 2, 2015", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"Monday, March 2, 2015 noon", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"Monday, March 2, 2015 + 1 day", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+		// the date wins unless parsing with VerifyWeekday
+		{"Tuesday, March 2, 2015", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"Monday 9am", time.Date(2015, 2, 16, 9, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	verifying := NewParser(VerifyWeekday())
+
+	for _, input := range []string{"Monday, March 2, 2015", "Tuesday, March 2", "Monday"} {
+		if _, err := verifying.Parse(input); err != nil {
+			t.Errorf("Parse(%q) with VerifyWeekday: %s", input, err)
+		}
+	}
+
+	if spec, err := verifying.Parse("Tuesday, March 2, 2015"); err == nil {
+		t.Errorf("Parse(%q) with VerifyWeekday: expected an error, got %s", "Tuesday, March 2, 2015", spec)
+	} else if !strings.Contains(err.Error(), "is a Monday, not a Tuesday") {
+		t.Errorf("Parse(%q) with VerifyWeekday: unexpected error %q", "Tuesday, March 2, 2015", err)
+	}
+}
+
+func TestParse_monthDaySplit(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		day   int
+	}{
+		{"March 2", 2},
+		{"March    2", 2},
+		{"March\t2", 2},
+		{"March \n\t 2", 2},
+		{"Mar2", 2},
+		{"March 02", 2},
+		{"March 12", 12},
+		{"noon March  2", 2},
+		{"noon March \t 2, 2015", 2},
+		{"March 2 noon", 2},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.month != time.March || spec.day != testcase.day {
+			t.Errorf("Parse(%q): expected March %d, got %s %d",
+				testcase.input, testcase.day, spec.month, spec.day)
+		}
+	}
+
+	for _, input := range []string{"March 123", "March", "March x"} {
+		if spec, err := NewParser(Strict()).Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error, got %s", input, spec)
+		}
+	}
+}
+
+func TestParseAbsolute(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+	later := time.Date(2016, 7, 9, 3, 45, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now", now},
+		{"now + 1 day", time.Date(2015, 3, 3, 15, 10, 0, 0, time.UTC)},
+		{"noon tomorrow", time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"10am Friday", time.Date(2015, 3, 6, 10, 0, 0, 0, time.UTC)},
+		{"9am Feb 12", time.Date(2016, 2, 12, 9, 0, 0, 0, time.UTC)},
+		{"14:00 Feb 12, 2015", time.Date(2015, 2, 12, 14, 0, 0, 0, time.UTC)},
+		{"end of month", time.Date(2015, 3, 31, 23, 59, 59, 0, time.UTC)},
+	} {
+		spec, err := ParseAbsolute(testcase.input, now)
+		if err != nil {
+			t.Errorf("ParseAbsolute(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !spec.IsAbsolute() {
+			t.Errorf("ParseAbsolute(%q): expected an absolute spec, got %#v", testcase.input, spec)
+		}
+
+		for _, reference := range []time.Time{now, later, {}} {
+			if resolved := spec.Resolve(reference); !resolved.Equal(testcase.expected) {
+				t.Errorf("ParseAbsolute(%q).Resolve(%s): expected %s, got %s",
+					testcase.input, reference, testcase.expected, resolved)
+			}
+		}
+	}
+}
+
+func TestParser_ParseAbsolute_options(t *testing.T) {
+	now := time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no zoneinfo for Europe/Berlin: %s", err)
+	}
+
+	for _, testcase := range []struct {
+		input    string
+		parser   *Parser
+		expected time.Time
+	}{
+		{"noon", NewParser(WithPostResolve(func(t time.Time) time.Time { return t.Add(time.Hour) })), time.Date(2015, 3, 2, 13, 0, 0, 0, time.UTC)},
+		{"noon", NewParser(WithFixedOffset(2 * time.Hour)), time.Date(2015, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{"noon Europe/Berlin tomorrow", NewParser(), time.Date(2015, 3, 3, 12, 0, 0, 0, berlin)},
+	} {
+		spec, err := testcase.parser.ParseAbsolute(testcase.input, now)
+		if err != nil {
+			t.Errorf("ParseAbsolute(%q): %s", testcase.input, err)
+			continue
+		}
+
+		for _, reference := range []time.Time{now, now.AddDate(1, 0, 0)} {
+			if resolved := spec.Resolve(reference); !resolved.Equal(testcase.expected) {
+				t.Errorf("ParseAbsolute(%q).Resolve(%s): expected %s, got %s",
+					testcase.input, reference, testcase.expected, resolved)
+			}
+		}
+	}
+
+	spec, err := ParseAbsolute("2015-03-02T14:30:00.25Z", now)
+	if err != nil {
+		t.Fatalf("ParseAbsolute(%q): %s", "2015-03-02T14:30:00.25Z", err)
+	}
+	if expected, resolved := time.Date(2015, 3, 2, 14, 30, 0, 250000000, time.UTC), spec.Resolve(now); !resolved.Equal(expected) {
+		t.Errorf("ParseAbsolute(%q).Resolve(now): expected %s, got %s", "2015-03-02T14:30:00.25Z", expected, resolved)
+	}
+
+	spec, err = NewParser(AllowPayload()).ParseAbsolute("10am tomorrow /usr/bin/backup", now)
+	if err != nil {
+		t.Fatalf("ParseAbsolute(%q): %s", "10am tomorrow /usr/bin/backup", err)
+	}
+	if offset, ok := spec.Payload(); !ok || offset != 14 {
+		t.Errorf("ParseAbsolute(%q).Payload(): expected 14, got %d, %v", "10am tomorrow /usr/bin/backup", offset, ok)
+	}
+}
+
+func TestParse_compactSeconds(t *testing.T) {
+	for _, testcase := range []struct {
+		input                   string
+		hours, minutes, seconds int
+	}{
+		{"143005", 14, 30, 5},
+		{"000000", 0, 0, 0},
+		{"235959", 23, 59, 59},
+		{"143005 Feb 12, 2015", 14, 30, 5},
+		{"1430", 14, 30, 0},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes || spec.seconds != testcase.seconds {
+			t.Errorf("Parse(%q): expected %02d:%02d:%02d, got %02d:%02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, testcase.seconds,
+				spec.hours, spec.minutes, spec.seconds)
+		}
+	}
+}
+
+func TestParse_compactSecondsInvalid(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		pos   int
+	}{
+		{"146005", 2},
+		{"143060", 4},
+		{"243005", 2},
+		{"14300", 5},
+	} {
+		_, err := Parse(testcase.input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", testcase.input)
+			continue
+		}
+
+		if perr, ok := err.(*ParseError); !ok || perr.Pos != testcase.pos {
+			t.Errorf("Parse(%q): expected an error at position %d, got %s", testcase.input, testcase.pos, err)
+		}
+	}
+}
+
+func TestParse_colonSeconds(t *testing.T) {
+	for _, testcase := range []struct {
+		input                   string
+		hours, minutes, seconds int
+	}{
+		{"14:15:30", 14, 15, 30},
+		{"00:00:00", 0, 0, 0},
+		{"23:59:59", 23, 59, 59},
+		{"2:15:30 pm", 14, 15, 30},
+		{"2:15:30am", 2, 15, 30},
+		{"14:15:30 UTC", 14, 15, 30},
+		{"14:15:30 +0200", 14, 15, 30},
+		{"14:15:30 Feb 12, 2015", 14, 15, 30},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if spec.hours != testcase.hours || spec.minutes != testcase.minutes || spec.seconds != testcase.seconds {
+			t.Errorf("Parse(%q): expected %02d:%02d:%02d, got %02d:%02d:%02d",
+				testcase.input, testcase.hours, testcase.minutes, testcase.seconds,
+				spec.hours, spec.minutes, spec.seconds)
+		}
+	}
+
+	for _, testcase := range []struct {
+		input string
+		pos   int
+	}{
+		{"14:15:60", 6},
+		{"2:15:75 pm", 5},
+		{"14:15:", 6},
+	} {
+		_, err := Parse(testcase.input)
+		if perr, ok := err.(*ParseError); !ok || perr.Pos != testcase.pos {
+			t.Errorf("Parse(%q): expected an error at position %d, got %v", testcase.input, testcase.pos, err)
+		}
+	}
+}
+
+func TestParse_empty(t *testing.T) {
+	for _, input := range []string{"", "   ", "\t\n"} {
+		for name, parse := range map[string]func(string) (*Timespec, error){
+			"Parse":          Parse,
+			"ParseTime":      ParseTime,
+			"ParseDate":      ParseDate,
+			"ParseIncrement": ParseIncrement,
+		} {
+			_, err := parse(input)
+			if err == nil {
+				t.Errorf("%s(%q): expected an error", name, input)
+				continue
+			}
+
+			perr, ok := err.(*ParseError)
+			if !ok || perr.Pos != 0 || perr.Msg != "timespec: empty timespec" {
+				t.Errorf("%s(%q): expected an empty timespec error at position 0, got %#v", name, input, err)
+			}
+		}
+	}
+}
+
+func TestTimespec_Resolve_numericOffset(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"14:00 -05:00", time.Date(2015, 3, 2, 19, 0, 0, 0, time.UTC)},
+		{"14:00 +02:00", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"14:00 +0200", time.Date(2015, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"2pm -0530 Feb 12, 2015", time.Date(2015, 2, 12, 19, 30, 0, 0, time.UTC)},
+		{"noon +01:00 tomorrow", time.Date(2015, 3, 3, 11, 0, 0, 0, time.UTC)},
+		{"14:00 -05:00 + 1 day", time.Date(2015, 3, 3, 19, 0, 0, 0, time.UTC)},
+		{"noon +1 hour", time.Date(2015, 3, 2, 13, 0, 0, 0, time.UTC)},
+		{"noon +0030 minutes", time.Date(2015, 3, 2, 12, 30, 0, 0, time.UTC)},
+		{"9am UTC+2", time.Date(2015, 3, 2, 7, 0, 0, 0, time.UTC)},
+		{"9am GMT-5", time.Date(2015, 3, 2, 14, 0, 0, 0, time.UTC)},
+		{"9am UTC+05:30", time.Date(2015, 3, 2, 3, 30, 0, 0, time.UTC)},
+		{"9am gmt+0530", time.Date(2015, 3, 2, 3, 30, 0, 0, time.UTC)},
+		{"9am GMT", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"9am UTC+1 day", time.Date(2015, 3, 3, 9, 0, 0, 0, time.UTC)},
+		{"Feb 12, 2015 UTC-8", time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_numericOffsetBeforeUnit(t *testing.T) {
+	now := time.Date(2015, 3, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"14:00 +02:00 day after tomorrow", time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"noon -01:30 day before yesterday", time.Date(2015, 2, 27, 13, 30, 0, 0, time.UTC)},
+		{"14:00 +0200 day", time.Date(2015, 9, 17, 14, 0, 0, 0, time.UTC)},
+		{"14:00 +0200 hours", time.Date(2015, 3, 9, 22, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_ianaTimezone(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("loading America/New_York: %s", err)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("loading Europe/Berlin: %s", err)
+	}
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"14:30 America/New_York", time.Date(2015, 3, 2, 14, 30, 0, 0, newYork)},
+		{"9am Europe/Berlin", time.Date(2015, 3, 2, 9, 0, 0, 0, berlin)},
+		{"9am Europe/Berlin tomorrow", time.Date(2015, 3, 3, 9, 0, 0, 0, berlin)},
+		{"noon Jul 01, 2015 Europe/Berlin", time.Date(2015, 7, 1, 12, 0, 0, 0, berlin)},
+		{"14:30 America/New_York + 1 week", time.Date(2015, 3, 9, 14, 30, 0, 0, newYork)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		resolved := spec.Resolve(now)
+		if !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+
+		if resolved.Location().String() != testcase.expected.Location().String() {
+			t.Errorf("Parse(%q).Resolve(now): expected location %s, got %s",
+				testcase.input, testcase.expected.Location(), resolved.Location())
+		}
+	}
+
+	_, err = Parse("14:30 Mars/Olympus_Mons")
+	if perr, ok := err.(*ParseError); !ok {
+		t.Errorf("Parse(%q): expected a *ParseError, got %#v", "14:30 Mars/Olympus_Mons", err)
+	} else if perr.Pos != len("14:30 ") {
+		t.Errorf("Parse(%q): expected an error at %d, got %s", "14:30 Mars/Olympus_Mons", len("14:30 "), perr)
+	}
+}
+
+func TestTimespec_Resolve_tomorrowAcrossBoundaries(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"midnight tomorrow", time.Date(2015, 12, 31, 15, 10, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"midnight tomorrow", time.Date(2015, 1, 31, 15, 10, 0, 0, time.UTC), time.Date(2015, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"midnight tomorrow", time.Date(2015, 2, 28, 15, 10, 0, 0, time.UTC), time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"midnight tomorrow", time.Date(2016, 2, 28, 15, 10, 0, 0, time.UTC), time.Date(2016, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow", time.Date(2015, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"noon tomorrow + 1 day", time.Date(2015, 12, 31, 8, 0, 0, 0, time.UTC), time.Date(2016, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{"midnight tonight", time.Date(2015, 12, 31, 8, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s",
+				testcase.input, testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_keywordTimeDateIncrement(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"midnight Feb 29, 2016 + 1 day", time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"midnight Feb 28, 2015 + 1 day", time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"noon Feb 29, 2016 + 1 day", time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"noon Feb 28, 2015 + 1 day", time.Date(2015, 3, 1, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestParse_incrementOverflow(t *testing.T) {
+	for _, input := range []string{
+		"now + 3000000000 minutes",
+		"noon + 99999999999999999999 days",
+		"14:00 Feb 12, 2015 + 100000001 years",
+		"now P100000001D",
+		"now PT100000001H",
+		"now + 1000001 centuries",
+		"noon + 10000001 decades",
+		"now + 14285715 weeks",
+		"now + 1 day + 1000001 centuries",
+		"now P14285715W",
+	} {
+		_, err := Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+			continue
+		}
+
+		if perr, ok := err.(*ParseError); !ok || perr.Msg != errIncrementTooLarge.Error() {
+			t.Errorf("Parse(%q): expected %q, got %#v", input, errIncrementTooLarge, err)
+		}
+	}
+
+	spec, err := Parse("now + 100000000 minutes")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "now + 100000000 minutes", err)
+	}
+
+	if count, _, _ := spec.Increment(); count != MaxIncrement {
+		t.Errorf("Parse(%q): expected %d increments, got %d", "now + 100000000 minutes", MaxIncrement, count)
+	}
+}
+
+func TestParse_incrementLimitPerUnit(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		count int
+		unit  Period
+	}{
+		{"now + 1000000 centuries", 1000000, Centuries},
+		{"now + 10000000 decades", 10000000, Decades},
+		{"now + 33333333 quarters", 33333333, Quarters},
+		{"now + 7142857 fortnights", 7142857, Fortnights},
+		{"now + 14285714 weeks", 14285714, Weeks},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if count, unit, _ := spec.Increment(); count != testcase.count || unit != testcase.unit {
+			t.Errorf("Parse(%q): expected %d %s, got %d %s",
+				testcase.input, testcase.count, testcase.unit, count, unit)
+		}
+	}
+}
+
+func TestTimespec_Resolve_bareYear(t *testing.T) {
+	now := time.Date(2015, 3, 2, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"in 2025", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"year 2025", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"noon in 2025", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"in 2025 + 2 months", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025", time.Date(2015, 3, 2, 20, 25, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	for _, input := range []string{"in 25", "in 20255", "year"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func TestTimespec_Resolve_dayAfterTomorrow(t *testing.T) {
+	now := time.Date(2015, 3, 1, 15, 10, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"day after tomorrow", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"day before yesterday", time.Date(2015, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"noon day after tomorrow", time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"9am  day   before  yesterday", time.Date(2015, 2, 27, 9, 0, 0, 0, time.UTC)},
+		{"day after tomorrow 10pm + 1 hour", time.Date(2015, 3, 3, 23, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	for _, input := range []string{"day after today", "day before tomorrow", "day"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func TestTimespec_Resolve_fromPhrase(t *testing.T) {
+	now := time.Date(2015, 2, 12, 9, 30, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input     string
+		expected  time.Time
+		canonical string
+	}{
+		{"+ 3 days from tomorrow", time.Date(2015, 2, 16, 0, 0, 0, 0, time.UTC), "tomorrow + 3 days"},
+		{"+ 3 days from today", time.Date(2015, 2, 15, 0, 0, 0, 0, time.UTC), "today + 3 days"},
+		{"+2 hours from now", time.Date(2015, 2, 12, 11, 30, 0, 0, time.UTC), "now + 2 hours"},
+		{"+ 1 week from tomorrow UTC", time.Date(2015, 2, 20, 0, 0, 0, 0, time.UTC), "tomorrow + 1 week UTC"},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.String(); actual != testcase.canonical {
+			t.Errorf("Parse(%q).String(): expected %q, got %q", testcase.input, testcase.canonical, actual)
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"+ 3 days from yesterday", "+ 3 days"} {
+		if spec, err := Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error, got %s", input, spec)
+		}
+	}
+
+	if _, err := NewParser(NoIncrements()).Parse("+ 3 days from now"); err == nil {
+		t.Errorf("Parse(%q) with NoIncrements: expected an error", "+ 3 days from now")
+	}
+
+	if _, err := Parse("+ 100000001 days from now"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "+ 100000001 days from now")
+	} else if perr, ok := err.(*ParseError); !ok || perr.Msg != errIncrementTooLarge.Error() {
+		t.Errorf("Parse(%q): expected %q, got %v", "+ 100000001 days from now", errIncrementTooLarge, err)
+	}
+}
+
+func TestParse_utcVariants(t *testing.T) {
+	for _, input := range []string{
+		"14:00 utc", "14:00 UTC", "14:00 Utc", "14:00 U.T.C.", "14:00 u.t.c.", "14:00 U.T.C",
+		"14:00 U.T.C. Feb 12", "14:00 u.t.c. + 1 day", "9:00 UTCnextweek",
+	} {
+		_, diagnostics, err := ParseWithDiagnostics(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		} else if len(diagnostics) != 0 {
+			t.Errorf("Parse(%q): expected no diagnostics, got %v", input, diagnostics)
+		}
+	}
+
+	spec, err := Parse("14:00 U.T.C. Feb 12, 2015 + 1 day")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "14:00 U.T.C. Feb 12, 2015 + 1 day", err)
+	}
+
+	expected := time.Date(2015, 2, 13, 14, 0, 0, 0, time.UTC)
+	if resolved := spec.Resolve(expected); !resolved.Equal(expected) {
+		t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", "14:00 U.T.C. Feb 12, 2015 + 1 day", expected, resolved)
+	}
+}
+
+func TestParse_trailingTimeZone(t *testing.T) {
+	for _, input := range []string{
+		"noon Feb 12 UTC", "Feb 12 UTC", "Tuesday UTC", "tomorrow UTC", "noon tomorrow utc",
+		"now + 1 day UTC", "noon + 1 day UTC", "Feb 12, 2015 + 1 week U.T.C.",
+	} {
+		spec, diagnostics, err := ParseWithDiagnostics(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+			continue
+		}
+
+		if len(diagnostics) != 0 {
+			t.Errorf("Parse(%q): expected no diagnostics, got %v", input, diagnostics)
+		}
+
+		if spec.zone != "UTC" {
+			t.Errorf("Parse(%q): expected zone %q, got %q", input, "UTC", spec.zone)
+		}
+	}
+}
+
+func TestParse_trailingTimeZoneFromTable(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	parser := NewParser(WithTimezoneTable(map[string]*time.Location{"EST": est}))
+	now := time.Date(2015, 2, 10, 3, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"noon Feb 12, 2015 EST", time.Date(2015, 2, 12, 12, 0, 0, 0, est)},
+		{"Feb 12, 2015 EST", time.Date(2015, 2, 12, 0, 0, 0, 0, est)},
+		// 03:00 UTC is still Feb 09 in EST
+		{"tomorrow EST", time.Date(2015, 2, 10, 0, 0, 0, 0, est)},
+		{"now + 1 day EST", now.AddDate(0, 0, 1)},
+	} {
+		spec, err := parser.Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if actual := spec.Resolve(now); !actual.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s",
+				testcase.input, testcase.expected, actual)
+		}
+
+		if again, err := parser.Parse(spec.String()); err != nil || !again.Resolve(now).Equal(testcase.expected) {
+			t.Errorf("Parse(%q): String() %q does not round-trip", testcase.input, spec.String())
+		}
+	}
+}
+
+// referenceParseClock is parseClock as it has been before scanning the
+// digits in a single pass.  It serves as the reference for that rewrite.
+func referenceParseClock(in io.ByteScanner, spec *Timespec) error {
+	c, _ := in.ReadByte()
+	buf := []byte{c}
+
+	c, _ = in.ReadByte()
+
+	if c != 0 {
+		if !isdigit(c) {
+			in.UnreadByte()
+			skip(in, isspace)
+		} else {
+			buf = append(buf, c)
+		}
+	}
+
+	hours, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return fmt.Errorf("clock: invalid number format: %s", buf)
+	}
+
+	if hours > 23 {
+		return fmt.Errorf("clock: invalid hours: %d", hours)
+	}
+
+	spec.hours = hours
+
+	c = peek(in)
+
+	// hours and minutes written as a four-digit number are always on
+	// the 24-hour clock
+	compact := isdigit(c)
+
+	if isdigit(c) || c == ':' {
+		if err := referenceParseMinute(in, spec); err != nil {
+			return err
+		}
+	}
+
+	c = skip(in, isspace)
+
+	if (c == 'n' || c == 'm') && spec.options().tolerantKeywords &&
+		spec.hours == 12 && spec.minutes == 0 {
+		return parseTime(in, spec)
+	}
+
+	meridiem := false
+	if c != 0 && strings.IndexByte("aApP", c) != -1 {
+		if meridiem, err = parseAmPm(in, spec); err != nil {
+			return err
+		}
+	}
+
+	if !meridiem && !compact && spec.hours >= 1 && spec.hours <= 12 {
+		switch spec.options().defaultMeridiem {
+		case MeridiemAM:
+			spec.hours = applyMeridiem(spec.hours, false)
+		case MeridiemPM:
+			spec.hours = applyMeridiem(spec.hours, true)
+		}
+	}
+
+	return parseOptionalTimeZone(in, spec)
+}
+
+// referenceParseMinute and referenceParseSecond read the minutes and
+// seconds for referenceParseClock.
+func referenceParseMinute(in io.ByteScanner, spec *Timespec) error {
+	c, _ := in.ReadByte()
+
+	if c == 0 {
+		return nil
+	}
+
+	if c == '.' && spec.options().dotSeparator {
+		c = ':'
+	}
+
+	if c != ':' && !isdigit(c) {
+		return fmt.Errorf("minute: expected ':' or digit, got '%c'", c)
+	} else if isdigit(c) {
+		in.UnreadByte()
+	} else if c != ':' {
+		return nil
+	}
+
+	buf := []byte{}
+	if c, ok := expectN(2, in, &buf, isdigit); !ok {
+		return fmt.Errorf("minute: expected digit, got '%c'", c)
+	}
+	minutes, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return fmt.Errorf("minute: %s", err)
+	}
+
+	if minutes >= 60 {
+		// report the error at the start of the minute digits
+		for range buf {
+			in.UnreadByte()
+		}
+		return fmt.Errorf("minute: invalid minutes: %d", minutes)
+	}
+
+	spec.minutes = minutes
+
+	// a six-digit time carries seconds as well, as in "143005", and so
+	// does one with another colon, as in "14:30:05"
+	if c != ':' && isdigit(peek(in)) {
+		return referenceParseSecond(in, spec)
+	} else if c == ':' && peek(in) == ':' {
+		in.ReadByte()
+		return referenceParseSecond(in, spec)
+	}
+
+	return nil
+}
+
+func referenceParseSecond(in io.ByteScanner, spec *Timespec) error {
+	buf := []byte{}
+	if c, ok := expectN(2, in, &buf, isdigit); !ok {
+		return fmt.Errorf("second: expected digit, got '%c'", c)
+	}
+
+	seconds, err := strconv.Atoi(string(buf))
+	if err != nil {
+		return fmt.Errorf("second: %s", err)
+	}
+
+	if seconds >= 60 {
+		// report the error at the start of the second digits
+		for range buf {
+			in.UnreadByte()
+		}
+		return fmt.Errorf("second: invalid seconds: %d", seconds)
+	}
+
+	spec.seconds = seconds
+
+	return nil
+}
+
+// clockInputs returns times to compare parseClock against
+// referenceParseClock with, valid as well as invalid ones.
+func clockInputs() []string {
+	inputs := []string{}
+
+	for _, hours := range []string{"0", "1", "9", "12", "23", "24", "99"} {
+		for _, separator := range []string{"", ":", " ", " :", "  "} {
+			for _, minutes := range []string{"", "0", "00", "30", "59", "60", "075"} {
+				for _, seconds := range []string{"", "00", "45", "60", "5", ":00", ":45", ":60", ":5", ":"} {
+					for _, suffix := range []string{"", "am", " pm", "a", " P", "x", " UTC", " +0200", " noon", " tomorrow", ":"} {
+						inputs = append(inputs, hours+separator+minutes+seconds+suffix)
+					}
+				}
+			}
+		}
+	}
+
+	return inputs
+}
+
+func TestParseClock_matchesReference(t *testing.T) {
+	parsers := []*Parser{
+		nil,
+		NewParser(TolerantKeywords(), SingleLetterMeridiem(), WithDefaultMeridiem(MeridiemPM)),
+	}
+
+	for _, parser := range parsers {
+		for _, input := range clockInputs() {
+			spec, err := parseWith(parser, input, parseClock)
+			expectedSpec, expectedErr := parseWith(parser, input, referenceParseClock)
+
+			if !reflect.DeepEqual(err, expectedErr) {
+				t.Errorf("parseClock(%q): expected error %v, got %v", input, expectedErr, err)
+			} else if !reflect.DeepEqual(spec, expectedSpec) {
+				t.Errorf("parseClock(%q):\n  Expected: %#v\n       Got: %#v", input, expectedSpec, spec)
+			}
+		}
+	}
+}
+
+var clockBenchmarkInputs = []string{"9", "14:15", "1415", "143005", "9:30 pm", "0512 UTC"}
+
+func BenchmarkParseClock(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, input := range clockBenchmarkInputs {
+			parseClock(&buffer{src: input}, &Timespec{})
+		}
+	}
+}
+
+func BenchmarkParseClock_reference(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, input := range clockBenchmarkInputs {
+			referenceParseClock(&buffer{src: input}, &Timespec{})
+		}
+	}
+}
+
+func TestTimespec_Resolve_chainedIncrements(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"now + 1 month + 15 days", time.Date(2015, 1, 10, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 25, 8, 0, 0, 0, time.UTC)},
+		{"14:00 + 1 day + 12 hours", time.Date(2015, 2, 28, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 2, 2, 0, 0, 0, time.UTC)},
+		// Jan 20 + 15 days is Feb 4, a month later is Mar 4
+		{"now + 15 days + 1 month", time.Date(2015, 1, 20, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 4, 8, 0, 0, 0, time.UTC)},
+		// Jan 20 + 1 month is Feb 20, 15 days later is Mar 7
+		{"now + 1 month + 15 days", time.Date(2015, 1, 20, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 7, 8, 0, 0, 0, time.UTC)},
+		{"now + 1 day - 2 hours next week", time.Date(2015, 3, 2, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 10, 6, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", testcase.input, testcase.now, testcase.expected, resolved)
+		}
+
+		if reparsed, err := Parse(spec.String()); err != nil {
+			t.Errorf("Parse(%q): %s", spec.String(), err)
+		} else if resolved := reparsed.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", spec.String(), testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Parse_chainedIncrementsStrict(t *testing.T) {
+	if _, err := NewParser(Strict()).Parse("now + 1 day + 2 parsecs"); err == nil {
+		t.Errorf("Parse(%q): expected an error for the second increment", "now + 1 day + 2 parsecs")
+	}
+}
+
+func TestTimespec_Resolve_yesterdayMonthBoundary(t *testing.T) {
+	spec, err := NewParser(Strict()).Parse("23:30 yesterday")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "23:30 yesterday", err)
+	}
+
+	for _, testcase := range []struct {
+		now      time.Time
+		expected time.Time
+	}{
+		{time.Date(2015, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2015, 2, 28, 23, 30, 0, 0, time.UTC)},
+		{time.Date(2015, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(2014, 12, 31, 23, 30, 0, 0, time.UTC)},
+	} {
+		if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", "23:30 yesterday", testcase.now, testcase.expected, resolved)
+		}
+	}
+}
+
+func TestTimespec_Resolve_incrementAfterDate(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		now      time.Time
+		expected time.Time
+	}{
+		{"tomorrow + 1 month", time.Date(2015, 1, 31, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"tomorrow + 1 month", time.Date(2015, 1, 30, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"noon yesterday + 1 month", time.Date(2015, 3, 1, 8, 0, 0, 0, time.UTC), time.Date(2015, 3, 28, 12, 0, 0, 0, time.UTC)},
+		{"day after tomorrow + 1 year", time.Date(2016, 2, 27, 8, 0, 0, 0, time.UTC), time.Date(2017, 3, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		for i := 0; i < 2; i++ {
+			if resolved := spec.Resolve(testcase.now); !resolved.Equal(testcase.expected) {
+				t.Errorf("Parse(%q).Resolve(%s): expected %s, got %s", testcase.input, testcase.now, testcase.expected, resolved)
+			}
+		}
+	}
+}
+
+func TestBuffer_UnreadByteAfterEOF(t *testing.T) {
+	buf := &buffer{src: "ab"}
+
+	buf.ReadByte()
+	buf.ReadByte()
+	if _, err := buf.ReadByte(); err != io.EOF {
+		t.Fatalf("ReadByte() at the end: expected io.EOF, got %v", err)
+	}
+
+	// nothing has been read by the failed read, so nothing is unread
+	buf.UnreadByte()
+	if buf.pos != 2 {
+		t.Errorf("UnreadByte() after io.EOF: expected position 2, got %d", buf.pos)
+	}
+
+	// a second UnreadByte backs up over the last byte read successfully
+	buf.UnreadByte()
+	if c, err := buf.ReadByte(); err != nil || c != 'b' {
+		t.Errorf("ReadByte() after backing up: expected 'b', got %q, %v", c, err)
+	}
+
+	buf.ReadByte()
+	rewind(buf, 1)
+	if c, _ := buf.ReadByte(); c != 'b' {
+		t.Errorf("ReadByte() after rewind: expected 'b', got %q", c)
+	}
+}
+
+func TestParseMonth_shortInput(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		day   int
+		err   bool
+	}{
+		{"", 0, true},
+		{" ", 0, true},
+		{"7", 7, false},
+		{" 7", 7, false},
+	} {
+		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
+		result := Timespec{}
+
+		err := parseMonth(src, &result)
+		if testcase.err != (err != nil) {
+			t.Errorf("parseMonth(%q): unexpected error result %v", testcase.input, err)
+		} else if result.day != testcase.day {
+			t.Errorf("parseMonth(%q): expected day %d, got %d", testcase.input, testcase.day, result.day)
+		}
+
+		if rest, _ := src.ReadString(0); rest != "" {
+			t.Errorf("parseMonth(%q): expected the input to be consumed, %q is left", testcase.input, rest)
+		}
+	}
+}
+
+func TestParseYear_shortInput(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		year  int
+		err   bool
+	}{
+		{"", 0, true},
+		{"2", 2, false},
+		{"201", 201, false},
+		{" 2015", 2015, false},
+	} {
+		src := bufio.NewReader(bytes.NewBufferString(testcase.input))
+		result := Timespec{}
+
+		err := parseYear(src, &result)
+		if testcase.err != (err != nil) {
+			t.Errorf("parseYear(%q): unexpected error result %v", testcase.input, err)
+		} else if result.year != testcase.year {
+			t.Errorf("parseYear(%q): expected year %d, got %d", testcase.input, testcase.year, result.year)
+		}
+
+		if rest, _ := src.ReadString(0); rest != "" {
+			t.Errorf("parseYear(%q): expected the input to be consumed, %q is left", testcase.input, rest)
+		}
+	}
+}
+
+func TestTimespec_Resolve_nowWithTimeZone(t *testing.T) {
+	berlin := time.FixedZone("CET", 60*60)
+	now := time.Date(2015, 3, 2, 0, 30, 0, 0, berlin)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"now UTC", now},
+		{"now utc", now},
+		{"now GMT", now},
+		{"now + 1 day UTC", now.AddDate(0, 0, 1)},
+		{"now UTC + 1 hour", now.Add(time.Hour)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected.UTC(), resolved)
+		}
+	}
+
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	if actual, err := ParseAndResolveIn("now UTC", now, loc); err != nil {
+		t.Errorf("ParseAndResolveIn(%q): %s", "now UTC", err)
+	} else if !actual.Equal(now) || actual.Location() != loc {
+		t.Errorf("ParseAndResolveIn(%q): expected %s, got %s", "now UTC", now.In(loc), actual)
+	}
+}
+
+func TestParser_Strict_dayOfMonth(t *testing.T) {
+	parser := NewParser(Strict())
+
+	for _, input := range []string{"Feb 29", "Feb 29, 2016", "Feb 29, 2000", "noon Feb 28, 2015", "Apr 30", "Jan 31"} {
+		if _, err := parser.Parse(input); err != nil {
+			t.Errorf("Parse(%q): %s", input, err)
+		}
+	}
+
+	for _, testcase := range []struct {
+		input string
+		pos   int
+	}{
+		{"Feb 30", 4},
+		{"Apr 31, 2015", 4},
+		{"Feb 29, 2015", 4},
+		{"Feb 29, 2100", 4},
+		{"noon Feb  30 + 1 day", 10},
+		{"Jun 31 10am", 4},
+	} {
+		_, err := parser.Parse(testcase.input)
+		if perr, ok := err.(*ParseError); !ok || perr.Pos != testcase.pos {
+			t.Errorf("Parse(%q): expected an error at position %d, got %v", testcase.input, testcase.pos, err)
+		}
+	}
+}
+
+func TestParser_Strict_leapDayWithoutYear(t *testing.T) {
+	spec, err := NewParser(Strict(), WithLeapDayPolicy(ErrorOnInvalid)).Parse("Feb 29")
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", "Feb 29", err)
+	}
+
+	// the next February 29 would be in 2017
+	if _, err := spec.ResolveChecked(time.Date(2016, 3, 2, 8, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("Parse(%q).ResolveChecked(now): expected an error", "Feb 29")
+	}
+
+	if _, err := spec.ResolveChecked(time.Date(2016, 1, 2, 8, 0, 0, 0, time.UTC)); err != nil {
+		t.Errorf("Parse(%q).ResolveChecked(now): %s", "Feb 29", err)
+	}
+}
+
+func TestTimespec_Bounds(t *testing.T) {
+	now := time.Date(2015, 6, 15, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input            string
+		earliest, latest time.Time
+		bounded          bool
+	}{
+		{"Feb 12", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC), time.Date(2016, 2, 12, 0, 0, 0, 0, time.UTC), true},
+		{"noon Dec 31", time.Date(2015, 12, 31, 12, 0, 0, 0, time.UTC), time.Date(2016, 12, 31, 12, 0, 0, 0, time.UTC), true},
+		{"Jan 1", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"14:00 Feb 12, 2014", time.Date(2014, 2, 12, 14, 0, 0, 0, time.UTC), time.Date(2014, 2, 12, 14, 0, 0, 0, time.UTC), true},
+		{"2015-03-02T14:30:00Z", time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC), time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC), true},
+		{"tomorrow", time.Time{}, time.Time{}, false},
+		{"now + 1 hour", time.Time{}, time.Time{}, false},
+		{"Friday", time.Time{}, time.Time{}, false},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		earliest, latest, bounded := spec.Bounds(now)
+		if bounded != testcase.bounded || !earliest.Equal(testcase.earliest) || !latest.Equal(testcase.latest) {
+			t.Errorf("Parse(%q).Bounds(now): expected [%s, %s] %v, got [%s, %s] %v", testcase.input,
+				testcase.earliest, testcase.latest, testcase.bounded, earliest, latest, bounded)
+		}
+
+		if bounded {
+			if resolved := spec.Resolve(now); resolved.Before(earliest) || resolved.After(latest) {
+				t.Errorf("Parse(%q).Resolve(now): %s is outside of its bounds", testcase.input, resolved)
+			}
+		}
+	}
+}
+
+func TestParse_isoDate(t *testing.T) {
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2015-03-02", time.Date(2015, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"09:00 2015-03-02", time.Date(2015, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"2015-12-31 + 1 day", time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2015-03-02 14:30", time.Date(2015, 3, 2, 14, 30, 0, 0, time.UTC)},
+		{"noon 2016-02-29 UTC", time.Date(2016, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{"2pm on 2015-03-02 next week", time.Date(2015, 3, 9, 14, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	for _, testcase := range []struct {
+		input string
+		pos   int
+	}{
+		{"2015-13-02", 5},
+		{"2015-00-02", 5},
+		{"2015-02-29", 8},
+		{"09:00 2015-04-31", 14},
+		{"2015-03-00 + 1 day", 8},
+	} {
+		_, err := NewParser(Strict()).Parse(testcase.input)
+		if perr, ok := err.(*ParseError); !ok || perr.Pos != testcase.pos {
+			t.Errorf("Parse(%q): expected an error at position %d, got %v", testcase.input, testcase.pos, err)
+		}
+	}
+}
+
+func TestFindNames_wholeToken(t *testing.T) {
+	for _, testcase := range []struct {
+		find     func([]byte) int
+		name     string
+		expected int
+	}{
+		{findMonth, "Mar", 3},
+		{findMonth, "March", 3},
+		{findMonth, "march", 3},
+		{findMonth, "Sept", 9},
+		{findMonth, "Marx", -1},
+		{findMonth, "Marchh", -1},
+		{findMonth, "Ma", -1},
+		{findDayOfWeek, "Wed", 2},
+		{findDayOfWeek, "Wednesday", 2},
+		{findDayOfWeek, "WEDNESDAY", 2},
+		{findDayOfWeek, "Thurs", 3},
+		{findDayOfWeek, "Wednesdayy", -1},
+		{findDayOfWeek, "Funday", -1},
+		{findPeriod, "day", int(Days)},
+		{findPeriod, "days", int(Days)},
+		{findPeriod, "centuries", int(Centuries)},
+		{findPeriod, "dayz", -1},
+		{findPeriod, "5days", -1},
+	} {
+		if actual := testcase.find([]byte(testcase.name)); actual != testcase.expected {
+			t.Errorf("find(%q): expected %d, got %d", testcase.name, testcase.expected, actual)
+		}
+	}
+
+	now := time.Date(2015, 2, 10, 8, 0, 0, 0, time.UTC)
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"feb 12", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"FEB 12", time.Date(2015, 2, 12, 0, 0, 0, 0, time.UTC)},
+		{"friday 9am", time.Date(2015, 2, 13, 9, 0, 0, 0, time.UTC)},
+		{"wed", time.Date(2015, 2, 11, 0, 0, 0, 0, time.UTC)},
+		{"march 3, 2015 noon", time.Date(2015, 3, 3, 12, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+		} else if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+
+	for _, input := range []string{"Marx 01", "noon Wednesdayy", "now + 1 dayz"} {
+		if _, err := NewParser(Strict()).Parse(input); err == nil {
+			t.Errorf("Parse(%q): expected an error", input)
+		}
+	}
+}
+
+func BenchmarkFindNames(b *testing.B) {
+	names := [][]byte{[]byte("March"), []byte("Dec"), []byte("Wednesday"), []byte("Sun"), []byte("minutes"), []byte("year")}
+
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			findMonth(name)
+			findDayOfWeek(name)
+			findPeriod(name)
+		}
+	}
+}
+
+func TestParse_onBeforeDate(t *testing.T) {
+	now := time.Date(2015, 2, 11, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected string
+	}{
+		{"9am on February 12", "9am February 12"},
+		{"noon on Monday", "noon Monday"},
+		{"on Monday", "Monday"},
+		{"on Monday 10am", "Monday 10am"},
+		{"ON friday", "Friday"},
+		{"9am on 2nd Tuesday of March", "9am 2nd Tuesday of March"},
+		{"on 2nd Tuesday of March", "2nd Tuesday of March"},
+		{"on 2015-03-02 9am", "2015-03-02 9am"},
+		{"10am on last Friday", "10am last Friday"},
+		{"9am on tomorrow", "9am tomorrow"},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		expected, err := NewParser(Strict()).Parse(testcase.expected)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", testcase.expected, err)
+		}
+
+		if actual, want := spec.Resolve(now), expected.Resolve(now); !actual.Equal(want) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, want, actual)
+		}
+	}
+
+	if _, err := NewParser(Strict()).Parse("9am on"); err == nil {
+		t.Errorf("Parse(%q): expected an error", "9am on")
+	}
+}
+
+func TestParse_yearWithoutComma(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		expected *Timespec
+	}{
+		{"Mar 02, 2015", &Timespec{month: 3, day: 2, year: 2015}},
+		{"Mar 02 2015", &Timespec{month: 3, day: 2, year: 2015}},
+		{"Mar 2  2015", &Timespec{month: 3, day: 2, year: 2015}},
+		{"Mar 02", &Timespec{month: 3, day: 2}},
+	} {
+		result, err := ParseDate(testcase.input)
+		if err != nil {
+			t.Errorf("ParseDate(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(result, testcase.expected) {
+			t.Errorf("ParseDate(%q):\n  Expected: %#v\n       Got: %#v\n", testcase.input, testcase.expected, result)
+		}
+	}
+
+	now := time.Date(2015, 2, 12, 8, 0, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		input    string
+		expected time.Time
+	}{
+		{"Mar 02 2015 + 1 day", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"noon Mar 02 2016", time.Date(2016, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"Mar 02 2016 noon", time.Date(2016, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{"Mar 02 10:00", time.Date(2015, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{"Mar 02 + 1 day", time.Date(2015, 3, 3, 0, 0, 0, 0, time.UTC)},
+	} {
+		spec, err := NewParser(Strict()).Parse(testcase.input)
+		if err != nil {
+			t.Errorf("Parse(%q): %s", testcase.input, err)
+			continue
+		}
+
+		if resolved := spec.Resolve(now); !resolved.Equal(testcase.expected) {
+			t.Errorf("Parse(%q).Resolve(now): expected %s, got %s", testcase.input, testcase.expected, resolved)
+		}
+	}
+}